@@ -0,0 +1,10 @@
+package waves
+
+import (
+	"embed"
+)
+
+// FS holds the default wave scripts of the game, one level per file.
+//
+//go:embed *.yaml
+var FS embed.FS
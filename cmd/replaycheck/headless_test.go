@@ -0,0 +1,47 @@
+package main
+
+import (
+	"go/build"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestHeadless checks replaycheck, and with it the simulation and replays, is
+// built without ebiten, so it runs on machines with no display and no cgo.
+func TestHeadless(t *testing.T) {
+	ctx := build.Default
+	ctx.GOOS, ctx.GOARCH = "linux", "amd64"
+	ctx.CgoEnabled = false
+
+	seen := map[string]bool{}
+	var walk func(path string, chain []string)
+	walk = func(path string, chain []string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+
+		dir := filepath.Join("..", "..", strings.TrimPrefix(path, "asteroid"))
+		pkg, err := ctx.ImportDir(dir, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		chain = append(chain, path)
+		for _, imp := range pkg.Imports {
+			switch {
+			case strings.HasPrefix(imp, "github.com/hajimehoshi/ebiten"):
+				t.Errorf("%s imports %s", strings.Join(chain, " -> "), imp)
+			case imp == "asteroid" || strings.HasPrefix(imp, "asteroid/"):
+				walk(imp, chain)
+			}
+		}
+	}
+	walk("asteroid/cmd/replaycheck", nil)
+
+	for _, path := range []string{"asteroid/sprite", "asteroid/simulation", "asteroid/replay"} {
+		if !seen[path] {
+			t.Errorf("%s is not checked", path)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Command replaycheck proves that games play out the same way every time. It
+// plays a replay twice and compares the hash of the world after every tick,
+// reporting the first tick the two runs diverge at and how their entities
+// differ there. Given the replaycheck binary of another build with -against,
+// it compares this build with that one instead.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"asteroid/replay"
+	"asteroid/simulation"
+)
+
+func main() {
+	against := flag.String("against", "", "replaycheck binary of another build to compare this build with")
+	hashes := flag.Bool("hashes", false, "print the tick and the hash of the world before the first and after every tick")
+	dump := flag.Int("dump", -1, "print the world after this tick as JSON")
+	workers := flag.Int("workers", 0, "goroutines moving large numbers of asteroids and bullets, 0 for none and -1 for one per CPU")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] file.replay\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	log.SetFlags(0)
+	if *workers != 0 {
+		pool = simulation.NewPool(*workers)
+	}
+	path := flag.Arg(0)
+	rec, err := replay.Load(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch {
+	case *hashes:
+		printHashes(rec)
+	case *dump >= 0:
+		printDump(rec, *dump)
+	case *against != "":
+		os.Exit(compareWith(rec, *against, path))
+	default:
+		os.Exit(compareRuns(rec))
+	}
+}
+
+// pool moves the entities of the worlds played back, when set with -workers.
+var pool *simulation.Pool
+
+// newPlayback starts playing back rec.
+func newPlayback(rec *replay.Recording) *replay.Playback {
+	pb, err := replay.NewPlayback(rec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pb.World.Pool = pool
+	return pb
+}
+
+// play calls fn with the tick and the world before the first tick and after
+// every tick of rec, until fn returns false.
+func play(rec *replay.Recording, fn func(tick uint64, w *simulation.World) bool) {
+	pb := newPlayback(rec)
+	for fn(pb.World.Clock.Ticks(), pb.World) && !pb.Done() {
+		pb.Step()
+	}
+}
+
+func printHashes(rec *replay.Recording) {
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	play(rec, func(tick uint64, w *simulation.World) bool {
+		fmt.Fprintf(out, "%d %016x\n", tick, w.Hash())
+		return true
+	})
+}
+
+func printDump(rec *replay.Recording, at int) {
+	var snap *simulation.Snapshot
+	play(rec, func(tick uint64, w *simulation.World) bool {
+		snap = w.Snapshot()
+		return tick < uint64(at)
+	})
+	if snap.Tick != uint64(at) {
+		log.Fatalf("the replay ends at tick %d", snap.Tick)
+	}
+	if err := snap.WriteJSON(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// compareRuns plays rec twice side by side and returns the exit code.
+func compareRuns(rec *replay.Recording) int {
+	a, b := newPlayback(rec), newPlayback(rec)
+	for {
+		if ha, hb := a.World.Hash(), b.World.Hash(); ha != hb {
+			report(a.World.Clock.Ticks(), a.World.Snapshot(), b.World.Snapshot(), "first run", "second run")
+			return 1
+		}
+		if a.Done() || b.Done() {
+			break
+		}
+		a.Step()
+		b.Step()
+	}
+	fmt.Printf("%d ticks played the same twice, final hash %016x\n", a.World.Clock.Ticks(), a.World.Hash())
+	return 0
+}
+
+// compareWith plays rec with this build and with the replaycheck binary of
+// another build and returns the exit code.
+func compareWith(rec *replay.Recording, other, path string) int {
+	cmd := exec.Command(other, "-hashes", path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	lines := bufio.NewScanner(stdout)
+	var diverged *simulation.World
+	var last, hash uint64
+	ended := false
+	play(rec, func(tick uint64, w *simulation.World) bool {
+		last, hash = tick, w.Hash()
+		if !lines.Scan() {
+			ended = true
+			return false
+		}
+		if lines.Text() != fmt.Sprintf("%d %016x", tick, hash) {
+			diverged = w
+			return false
+		}
+		return true
+	})
+	switch {
+	case ended:
+		fmt.Printf("the other build ends before tick %d\n", last)
+		return 1
+	case diverged == nil && lines.Scan():
+		fmt.Printf("the other build plays past tick %d, where this one ends\n", last)
+		return 1
+	case diverged == nil:
+		fmt.Printf("%d ticks played the same by both builds, final hash %016x\n", last, hash)
+		return 0
+	}
+
+	out, err := exec.Command(other, "-dump", strconv.FormatUint(last, 10), path).Output()
+	if err != nil {
+		fmt.Printf("the builds diverge at tick %d, the other build can not dump it: %v\n", last, err)
+		return 1
+	}
+	theirs, err := simulation.ReadSnapshot(bytes.NewReader(out))
+	if err != nil {
+		log.Fatal(err)
+	}
+	report(last, diverged.Snapshot(), theirs, "this build", "other build")
+	return 1
+}
+
+// report prints where and how a and b diverged.
+func report(tick uint64, a, b *simulation.Snapshot, aName, bName string) {
+	fmt.Printf("diverged at tick %d: %s %016x, %s %016x\n", tick, aName, a.Hash(), bName, b.Hash())
+	fmt.Printf("%s != %s:\n", aName, bName)
+	for _, d := range a.Diff(b) {
+		fmt.Println("  " + d)
+	}
+}
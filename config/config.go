@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config holds the settings a game is played with. Durations are parsed once,
+// when the settings are loaded.
+type Config struct {
+	Screen   Screen   `yaml:"screen"`
+	Player   Player   `yaml:"player"`
+	Bullet   Bullet   `yaml:"bullet"`
+	Asteroid Asteroid `yaml:"asteroid"`
+	Wave     Wave     `yaml:"wave"`
+}
+
+type Screen struct {
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+}
+
+type Player struct {
+	Radius        int     `yaml:"radius"`
+	MoveSpeed     float64 `yaml:"move_speed"`
+	RotationSpeed float64 `yaml:"rotation_speed"` // degrees per second
+	// Thrust is in pixels per second squared and Drag the share of the velocity
+	// lost per second, both only used by inertial flight.
+	Thrust   float64       `yaml:"thrust"`
+	Drag     float64       `yaml:"drag"`
+	FireRate time.Duration `yaml:"fire_rate"`
+	Lives    int           `yaml:"lives"`
+	// RespawnClearance is the distance around the respawn point which must be
+	// free of asteroids before the ship comes back.
+	RespawnDelay     time.Duration `yaml:"respawn_delay"`
+	RespawnClearance float64       `yaml:"respawn_clearance"`
+	InvulnerableTime time.Duration `yaml:"invulnerable_time"`
+}
+
+type Bullet struct {
+	Radius int     `yaml:"radius"`
+	Speed  float64 `yaml:"speed"`
+	// WrapRange is the distance bullets wrapping around the screen fly.
+	WrapRange float64 `yaml:"wrap_range"`
+}
+
+type Asteroid struct {
+	// MinRadius is the radius of the smallest of Kinds sizes, every larger
+	// size is a multiple of it.
+	MinRadius int           `yaml:"min_radius"`
+	Kinds     int           `yaml:"kinds"`
+	SpawnRate time.Duration `yaml:"spawn_rate"`
+	MinSpeed  float64       `yaml:"min_speed"`
+	MaxSpeed  float64       `yaml:"max_speed"`
+	MaxAngle  float64       `yaml:"max_angle"` // degrees
+}
+
+type Wave struct {
+	// Intermission is the time between clearing a wave and the next one.
+	Intermission time.Duration `yaml:"intermission"`
+}
+
+// Default returns the settings the game is tuned for.
+func Default() Config {
+	return Config{
+		Screen: Screen{
+			Width:  1280,
+			Height: 720,
+		},
+		Player: Player{
+			Radius:           20,
+			MoveSpeed:        200,
+			RotationSpeed:    300,
+			Thrust:           300,
+			Drag:             0.5,
+			FireRate:         300 * time.Millisecond,
+			Lives:            3,
+			RespawnDelay:     time.Second,
+			RespawnClearance: 150,
+			InvulnerableTime: 3 * time.Second,
+		},
+		Bullet: Bullet{
+			Radius:    5,
+			Speed:     500,
+			WrapRange: 800,
+		},
+		Asteroid: Asteroid{
+			MinRadius: 20,
+			Kinds:     3,
+			SpawnRate: 800 * time.Millisecond,
+			MinSpeed:  40,
+			MaxSpeed:  100,
+			MaxAngle:  30,
+		},
+		Wave: Wave{
+			Intermission: 3 * time.Second,
+		},
+	}
+}
+
+// MaxRadius returns the radius of the largest asteroid.
+func (a Asteroid) MaxRadius() int {
+	return a.MinRadius * a.Kinds
+}
+
+// Validate reports every setting which is out of range.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, name string, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s", name, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(c.Screen.Width > 0, "screen.width", "must be positive")
+	check(c.Screen.Height > 0, "screen.height", "must be positive")
+
+	check(c.Player.Radius > 0, "player.radius", "must be positive")
+	check(c.Player.MoveSpeed > 0, "player.move_speed", "must be positive")
+	check(c.Player.RotationSpeed > 0, "player.rotation_speed", "must be positive")
+	check(c.Player.Thrust > 0, "player.thrust", "must be positive")
+	check(c.Player.Drag >= 0, "player.drag", "must not be negative")
+	check(c.Player.FireRate > 0, "player.fire_rate", "must be positive")
+	check(c.Player.Lives > 0, "player.lives", "must be positive")
+	check(c.Player.RespawnDelay >= 0, "player.respawn_delay", "must not be negative")
+	check(c.Player.RespawnClearance >= 0, "player.respawn_clearance", "must not be negative")
+	check(c.Player.InvulnerableTime >= 0, "player.invulnerable_time", "must not be negative")
+
+	check(c.Bullet.Radius > 0, "bullet.radius", "must be positive")
+	check(c.Bullet.Speed > 0, "bullet.speed", "must be positive")
+	check(c.Bullet.WrapRange > 0, "bullet.wrap_range", "must be positive")
+
+	check(c.Asteroid.MinRadius > 0, "asteroid.min_radius", "must be positive")
+	check(c.Asteroid.Kinds > 0, "asteroid.kinds", "must be positive")
+	check(c.Asteroid.SpawnRate > 0, "asteroid.spawn_rate", "must be positive")
+	check(c.Asteroid.MinSpeed >= 0, "asteroid.min_speed", "must not be negative")
+	check(c.Asteroid.MaxSpeed > 0, "asteroid.max_speed", "must be positive")
+	check(c.Asteroid.MaxSpeed >= c.Asteroid.MinSpeed, "asteroid.max_speed", "%g is below asteroid.min_speed %g", c.Asteroid.MaxSpeed, c.Asteroid.MinSpeed)
+	check(c.Asteroid.MaxAngle >= 0, "asteroid.max_angle", "must not be negative")
+
+	check(c.Wave.Intermission >= 0, "wave.intermission", "must not be negative")
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	// the largest asteroid has to fit between the edges it spawns on
+	if d := 2 * c.Asteroid.MaxRadius(); d > min(c.Screen.Width, c.Screen.Height) {
+		return fmt.Errorf("asteroids of radius %d do not fit on a %dx%d screen", c.Asteroid.MaxRadius(), c.Screen.Width, c.Screen.Height)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+)
+
+func TestDefault(t *testing.T) {
+	assert := assert.New(t)
+	c := config.Default()
+	assert.NoError(c.Validate())
+	assert.Equal(1280, c.Screen.Width)
+	assert.Equal(300*time.Millisecond, c.Player.FireRate)
+	assert.Equal(60, c.Asteroid.MaxRadius())
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	c := config.Default()
+	c.Player.Radius = 0
+	c.Player.FireRate = -time.Second
+	c.Asteroid.MinSpeed = 120
+	err := c.Validate()
+	assert.ErrorContains(err, "player.radius must be positive")
+	assert.ErrorContains(err, "player.fire_rate must be positive")
+	assert.ErrorContains(err, "asteroid.max_speed 100 is below asteroid.min_speed 120")
+
+	c = config.Default()
+	c.Screen.Height = 100
+	assert.ErrorContains(c.Validate(), "asteroids of radius 60 do not fit on a 1280x100 screen")
+}
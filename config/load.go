@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"asteroid/storage"
+)
+
+// DefaultPath returns the settings file under the user's config directory,
+// which is read when present.
+func DefaultPath() (string, error) {
+	return storage.Path("config.yaml")
+}
+
+// Load returns the defaults overridden by the settings in the YAML or JSON
+// file at path. Settings left out of the file keep their defaults.
+func Load(path string) (Config, error) {
+	c := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if err := c.decode(path, data); err != nil {
+		return c, err
+	}
+	return c, c.Validate()
+}
+
+// decode reads the settings in data over c, rejecting unknown settings.
+func (c *Config) decode(path string, data []byte) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && err != io.EOF {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Source is where settings come from: the defaults, overridden by a settings
+// file, overridden by the flags given on the command line.
+type Source struct {
+	// Path is the settings file, empty if there is none. A missing file only
+	// counts as an error when Required is set.
+	Path     string
+	Required bool
+	// flags are the names and values of the settings given as flags.
+	flags [][2]string
+}
+
+// ParseFlags registers -config and a flag for every setting, such as
+// -player.move_speed, on set, parses args and returns where the settings come
+// from. Without -config the file under DefaultPath is used when present.
+func ParseFlags(set *flag.FlagSet, args []string) (*Source, error) {
+	c := Default()
+	configPath := set.String("config", "", "settings file in YAML or JSON (default under the user config directory)")
+	names := c.flags(set)
+	if err := set.Parse(args); err != nil {
+		return nil, err
+	}
+
+	src := &Source{Path: *configPath, Required: *configPath != ""}
+	if !src.Required {
+		if path, err := DefaultPath(); err == nil {
+			src.Path = path
+		}
+	}
+	set.Visit(func(f *flag.Flag) {
+		if _, ok := names[f.Name]; ok {
+			src.flags = append(src.flags, [2]string{f.Name, f.Value.String()})
+		}
+	})
+	return src, nil
+}
+
+// Load reads the settings, validated.
+func (s *Source) Load() (Config, error) {
+	c := Default()
+	if s.Path != "" {
+		data, err := os.ReadFile(s.Path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !s.Required:
+		case err != nil:
+			return c, err
+		default:
+			if err := c.decode(s.Path, data); err != nil {
+				return c, err
+			}
+		}
+	}
+
+	set := flag.NewFlagSet("settings", flag.ContinueOnError)
+	c.flags(set)
+	for _, f := range s.flags {
+		if err := set.Set(f[0], f[1]); err != nil {
+			return c, err
+		}
+	}
+	return c, c.Validate()
+}
+
+// flags registers a flag for every setting of c on set and returns their names.
+func (c *Config) flags(set *flag.FlagSet) map[string]struct{} {
+	names := map[string]struct{}{}
+	intVar := func(p *int, name, usage string) {
+		set.IntVar(p, name, *p, usage)
+		names[name] = struct{}{}
+	}
+	floatVar := func(p *float64, name, usage string) {
+		set.Float64Var(p, name, *p, usage)
+		names[name] = struct{}{}
+	}
+	durationVar := func(p *time.Duration, name, usage string) {
+		set.DurationVar(p, name, *p, usage)
+		names[name] = struct{}{}
+	}
+
+	intVar(&c.Screen.Width, "screen.width", "width of the playing field in pixels")
+	intVar(&c.Screen.Height, "screen.height", "height of the playing field in pixels")
+
+	intVar(&c.Player.Radius, "player.radius", "radius of the ship")
+	floatVar(&c.Player.MoveSpeed, "player.move_speed", "speed of the ship in pixels per second")
+	floatVar(&c.Player.RotationSpeed, "player.rotation_speed", "turn rate of the ship in degrees per second")
+	floatVar(&c.Player.Thrust, "player.thrust", "acceleration of the ship in inertial flight")
+	floatVar(&c.Player.Drag, "player.drag", "share of the velocity lost per second in inertial flight")
+	durationVar(&c.Player.FireRate, "player.fire_rate", "time between two shots")
+	intVar(&c.Player.Lives, "player.lives", "lives at the start of a game")
+	durationVar(&c.Player.RespawnDelay, "player.respawn_delay", "time before the ship comes back after losing a life")
+	floatVar(&c.Player.RespawnClearance, "player.respawn_clearance", "distance around the respawn point free of asteroids")
+	durationVar(&c.Player.InvulnerableTime, "player.invulnerable_time", "invulnerability after respawning")
+
+	intVar(&c.Bullet.Radius, "bullet.radius", "radius of the bullets")
+	floatVar(&c.Bullet.Speed, "bullet.speed", "speed of the bullets in pixels per second")
+	floatVar(&c.Bullet.WrapRange, "bullet.wrap_range", "distance flown by bullets wrapping around the screen")
+
+	intVar(&c.Asteroid.MinRadius, "asteroid.min_radius", "radius of the smallest asteroids")
+	intVar(&c.Asteroid.Kinds, "asteroid.kinds", "number of asteroid sizes")
+	durationVar(&c.Asteroid.SpawnRate, "asteroid.spawn_rate", "time between two asteroids of the first wave")
+	floatVar(&c.Asteroid.MinSpeed, "asteroid.min_speed", "lowest asteroid speed in pixels per second")
+	floatVar(&c.Asteroid.MaxSpeed, "asteroid.max_speed", "highest asteroid speed in pixels per second")
+	floatVar(&c.Asteroid.MaxAngle, "asteroid.max_angle", "typical deviation of asteroids from the edge normal in degrees")
+
+	durationVar(&c.Wave.Intermission, "wave.intermission", "time between clearing a wave and the next one")
+	return names
+}
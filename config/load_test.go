@@ -0,0 +1,99 @@
+package config_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+)
+
+func writeFile(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := config.Load(writeFile(t, "config.yaml", `
+player:
+  move_speed: 250
+  fire_rate: 150ms
+asteroid:
+  kinds: 4
+`))
+	assert.NoError(err)
+	want := config.Default()
+	want.Player.MoveSpeed = 250
+	want.Player.FireRate = 150 * time.Millisecond
+	want.Asteroid.Kinds = 4
+	assert.Equal(want, c, "settings left out keep their defaults")
+
+	c, err = config.Load(writeFile(t, "config.json", `{"bullet": {"speed": 900}}`))
+	assert.NoError(err)
+	assert.Equal(900.0, c.Bullet.Speed)
+
+	c, err = config.Load(writeFile(t, "empty.yaml", ""))
+	assert.NoError(err)
+	assert.Equal(config.Default(), c)
+}
+
+func TestLoadErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := config.Load(writeFile(t, "config.yaml", "player:\n  move_sped: 250\n"))
+	assert.ErrorContains(err, "line 2: field move_sped not found")
+
+	_, err = config.Load(writeFile(t, "config.yaml", "player:\n  fire_rate: 0.3\n"))
+	assert.ErrorContains(err, "config.yaml", "durations need a unit")
+
+	_, err = config.Load(writeFile(t, "config.yaml", "bullet:\n  radius: -1\n"))
+	assert.ErrorContains(err, "bullet.radius must be positive")
+
+	_, err = config.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(err)
+}
+
+func TestParseFlags(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := writeFile(t, "config.yaml", "player:\n  lives: 5\n  move_speed: 250\n")
+
+	src, err := config.ParseFlags(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	assert.NoError(err)
+	assert.False(src.Required)
+	c, err := src.Load()
+	assert.NoError(err, "a missing default file is not an error")
+	assert.Equal(config.Default(), c)
+
+	args := []string{"-config", path, "-player.move_speed", "300", "-asteroid.spawn_rate", "1.5s"}
+	src, err = config.ParseFlags(flag.NewFlagSet("test", flag.ContinueOnError), args)
+	assert.NoError(err)
+	assert.Equal(path, src.Path)
+	c, err = src.Load()
+	assert.NoError(err)
+	assert.Equal(5, c.Player.Lives, "the file overrides the defaults")
+	assert.Equal(300.0, c.Player.MoveSpeed, "flags override the file")
+	assert.Equal(1500*time.Millisecond, c.Asteroid.SpawnRate)
+
+	src, err = config.ParseFlags(flag.NewFlagSet("test", flag.ContinueOnError), []string{"-config", path + ".missing"})
+	assert.NoError(err)
+	_, err = src.Load()
+	assert.Error(err, "a file given on the command line has to exist")
+
+	src, err = config.ParseFlags(flag.NewFlagSet("test", flag.ContinueOnError), []string{"-player.lives", "0"})
+	assert.NoError(err)
+	_, err = src.Load()
+	assert.ErrorContains(err, "player.lives must be positive")
+
+	_, err = config.ParseFlags(flag.NewFlagSet("test", flag.ContinueOnError), []string{"-player.lives", "many"})
+	assert.Error(err)
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// Watcher notices edits to the settings file of a Source by polling it, so
+// settings can be tuned while the game is running.
+type Watcher struct {
+	Source *Source
+	// Interval is the least time between two looks at the file.
+	Interval time.Duration
+
+	lastPoll time.Time
+	stamp    fileStamp
+}
+
+// fileStamp tells versions of a file apart.
+type fileStamp struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher creates a Watcher of the file of src as it is now.
+func NewWatcher(src *Source, interval time.Duration) *Watcher {
+	return &Watcher{
+		Source:   src,
+		Interval: interval,
+		stamp:    stampOf(src.Path),
+	}
+}
+
+func stampOf(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{exists: true, modTime: info.ModTime(), size: info.Size()}
+}
+
+// Poll loads the settings again if the file changed since it was last seen,
+// looking at it at most once per Interval. changed is false when it did not
+// look or nothing changed, err is set when the changed file is invalid.
+func (w *Watcher) Poll(now time.Time) (c Config, changed bool, err error) {
+	if w.Source.Path == "" || now.Sub(w.lastPoll) < w.Interval {
+		return c, false, nil
+	}
+	w.lastPoll = now
+
+	stamp := stampOf(w.Source.Path)
+	if stamp == w.stamp {
+		return c, false, nil
+	}
+	w.stamp = stamp
+	c, err = w.Source.Load()
+	return c, true, err
+}
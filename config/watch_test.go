@@ -0,0 +1,49 @@
+package config_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	assert := assert.New(t)
+	path := writeFile(t, "config.yaml", "player:\n  move_speed: 250\n")
+	src := &config.Source{Path: path, Required: true}
+	w := config.NewWatcher(src, time.Second)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, changed, err := w.Poll(now)
+	assert.False(changed, "the file is as it was when the watch started")
+	assert.NoError(err)
+
+	edit := func(data string, mod time.Time) {
+		assert.NoError(os.WriteFile(path, []byte(data), 0o644))
+		assert.NoError(os.Chtimes(path, mod, mod))
+	}
+
+	edit("player:\n  move_speed: 300\n", now.Add(time.Minute))
+	_, changed, _ = w.Poll(now.Add(time.Second / 2))
+	assert.False(changed, "the file is looked at once per interval")
+	c, changed, err := w.Poll(now.Add(time.Second))
+	assert.True(changed)
+	assert.NoError(err)
+	assert.Equal(300.0, c.Player.MoveSpeed)
+
+	_, changed, _ = w.Poll(now.Add(2 * time.Second))
+	assert.False(changed)
+
+	edit("player:\n  move_speed: -1\n", now.Add(2*time.Minute))
+	_, changed, err = w.Poll(now.Add(3 * time.Second))
+	assert.True(changed)
+	assert.ErrorContains(err, "player.move_speed must be positive")
+
+	assert.NoError(os.Remove(path))
+	_, changed, err = w.Poll(now.Add(4 * time.Second))
+	assert.True(changed)
+	assert.Error(err, "a required file was removed")
+}
@@ -0,0 +1,72 @@
+package control
+
+import (
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"asteroid/utils"
+	"math"
+)
+
+// Bot flies the ship on its own. It turns towards the nearest asteroid, fires
+// once it is lined up and backs away from asteroids coming too close. It only
+// looks at the world, so it plays the same way on every replay.
+type Bot struct {
+	// Aim is how far off the heading of the ship, in degrees, the target may
+	// be to be shot at.
+	Aim float64
+	// Comfort is the distance to the edge of the target under which the bot
+	// backs away.
+	Comfort float64
+}
+
+func NewBot() *Bot {
+	return &Bot{Aim: 5, Comfort: 100}
+}
+
+func (b *Bot) Command(w *simulation.World) sprite.Command {
+	var cmd sprite.Command
+	if w.IsRespawning() {
+		return cmd
+	}
+	target, d := b.nearest(w)
+	if target == nil {
+		return cmd
+	}
+
+	to := target.Center.Clone().Sub(w.Player.Center)
+	switch angle := heading(w.Player.Direction, *to); {
+	case angle > b.Aim:
+		cmd.RotateRight = true
+	case angle < -b.Aim:
+		cmd.RotateLeft = true
+	default:
+		cmd.Fire = true
+	}
+	cmd.Reverse = d-float64(target.Radius) < b.Comfort
+	return cmd
+}
+
+// nearest returns the closest asteroid on the field to the ship and its
+// distance, or nil if there is none.
+func (b *Bot) nearest(w *simulation.World) (*sprite.Asteroid, float64) {
+	var target *sprite.Asteroid
+	best := math.Inf(1)
+	for _, a := range w.AsteroidCtrl.Asteroids {
+		if a.IsDestoryed() {
+			continue
+		}
+		d := utils.Distance(w.Player.Center.X, w.Player.Center.Y, a.Center.X, a.Center.Y)
+		if d < best {
+			target, best = a, d
+		}
+	}
+	return target, best
+}
+
+// heading returns the angle in degrees from dir to v, positive clockwise on
+// screen.
+func heading(dir, v utils.Vector2) float64 {
+	cross := dir.X*v.Y - dir.Y*v.X
+	dot := dir.X*v.X + dir.Y*v.Y
+	return math.Atan2(cross, dot) * 180 / math.Pi
+}
@@ -0,0 +1,47 @@
+package control_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/control"
+	"asteroid/sprite"
+	"asteroid/utils"
+)
+
+func TestBot(t *testing.T) {
+	assert := assert.New(t)
+	b := control.NewBot()
+	w := newTestWorld()
+	// the ship faces up from the center of the screen at 640, 360
+	assert.Equal(sprite.Command{}, b.Command(w), "nothing to shoot at")
+
+	cases := []struct {
+		name   string
+		center utils.Vector2
+		want   sprite.Command
+	}{
+		{"ahead", utils.Vector2{X: 640, Y: 100}, sprite.Command{Fire: true}},
+		{"right", utils.Vector2{X: 900, Y: 360}, sprite.Command{RotateRight: true}},
+		{"behind on the left", utils.Vector2{X: 500, Y: 600}, sprite.Command{RotateLeft: true}},
+		{"too close", utils.Vector2{X: 640, Y: 250}, sprite.Command{Fire: true, Reverse: true}},
+	}
+	for _, c := range cases {
+		w := newTestWorld()
+		w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(c.center, 20, 0, *utils.NewVector2(1, 0)))
+		// a farther asteroid is ignored
+		w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 100, Y: 100}, 20, 0, *utils.NewVector2(1, 0)))
+		assert.Equal(c.want, b.Command(w), c.name)
+	}
+}
+
+func TestBotClearsAWave(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	b := control.NewBot()
+	for range 60 * 60 {
+		w.Step(b.Command(w))
+	}
+	assert.Greater(w.Score, 0, "the bot should shoot some asteroids down")
+}
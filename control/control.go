@@ -0,0 +1,15 @@
+// Package control flies the ship of a world: whoever or whatever is at the
+// controls tells it what to do one tick at a time.
+package control
+
+import (
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+// ShipController decides the command the ship of a world follows on its next
+// tick. It is asked exactly once per tick, in order, so controllers may keep
+// state between ticks.
+type ShipController interface {
+	Command(w *simulation.World) sprite.Command
+}
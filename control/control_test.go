@@ -0,0 +1,14 @@
+package control_test
+
+import (
+	"asteroid/config"
+	"asteroid/simulation"
+)
+
+func newTestWorld() *simulation.World {
+	rules, err := simulation.RulesForMode(simulation.ModeArcade)
+	if err != nil {
+		panic(err)
+	}
+	return simulation.NewWorld(config.Default(), 1, rules)
+}
@@ -0,0 +1,81 @@
+package control
+
+import (
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"io"
+)
+
+// Remote flies the ship with the commands of a peer, read from Conn one byte
+// per tick as written by Send. Reading blocks until the peer has sent the
+// command of the tick, which keeps both sides in lockstep. Once Conn fails
+// the ship is left alone and Err reports why.
+type Remote struct {
+	Conn io.Reader
+
+	err error
+	buf [1]byte
+}
+
+func NewRemote(conn io.Reader) *Remote {
+	return &Remote{Conn: conn}
+}
+
+func (r *Remote) Command(*simulation.World) sprite.Command {
+	if r.err != nil {
+		return sprite.Command{}
+	}
+	if _, err := io.ReadFull(r.Conn, r.buf[:]); err != nil {
+		r.err = err
+		return sprite.Command{}
+	}
+	return Decode(r.buf[0])
+}
+
+// Err returns the error which ended reading from the peer, nil while it is
+// connected.
+func (r *Remote) Err() error {
+	return r.err
+}
+
+// Send writes cmd to the peer flying the ship with a Remote.
+func Send(w io.Writer, cmd sprite.Command) error {
+	_, err := w.Write([]byte{Encode(cmd)})
+	return err
+}
+
+// The bits of the byte a command is sent and recorded as.
+const (
+	bitThrust = 1 << iota
+	bitReverse
+	bitRotateLeft
+	bitRotateRight
+	bitFire
+)
+
+// Encode returns the byte cmd is sent and recorded as.
+func Encode(cmd sprite.Command) byte {
+	var b byte
+	set := func(on bool, bit byte) {
+		if on {
+			b |= bit
+		}
+	}
+	set(cmd.Thrust, bitThrust)
+	set(cmd.Reverse, bitReverse)
+	set(cmd.RotateLeft, bitRotateLeft)
+	set(cmd.RotateRight, bitRotateRight)
+	set(cmd.Fire, bitFire)
+	return b
+}
+
+// Decode returns the command encoded as b.
+func Decode(b byte) sprite.Command {
+	return sprite.Command{
+		Thrust:      b&bitThrust != 0,
+		Reverse:     b&bitReverse != 0,
+		RotateLeft:  b&bitRotateLeft != 0,
+		RotateRight: b&bitRotateRight != 0,
+		Fire:        b&bitFire != 0,
+	}
+}
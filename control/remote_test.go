@@ -0,0 +1,36 @@
+package control_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/control"
+	"asteroid/sprite"
+)
+
+func TestRemote(t *testing.T) {
+	assert := assert.New(t)
+	commands := []sprite.Command{
+		{},
+		{Thrust: true, Fire: true},
+		{Reverse: true, RotateLeft: true},
+		{Thrust: true, Reverse: true, RotateLeft: true, RotateRight: true, Fire: true},
+	}
+	var conn bytes.Buffer
+	for _, cmd := range commands {
+		assert.NoError(control.Send(&conn, cmd))
+	}
+	assert.Equal(len(commands), conn.Len(), "a command is a byte")
+
+	w := newTestWorld()
+	r := control.NewRemote(&conn)
+	for _, want := range commands {
+		assert.Equal(want, r.Command(w))
+		assert.NoError(r.Err())
+	}
+	assert.Equal(sprite.Command{}, r.Command(w), "the peer is gone")
+	assert.ErrorIs(r.Err(), io.EOF)
+}
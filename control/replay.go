@@ -0,0 +1,45 @@
+package control
+
+import (
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+// Recorder passes on the commands of Controller, keeping every one of them so
+// the game can be replayed.
+type Recorder struct {
+	Controller ShipController
+	Commands   []sprite.Command
+}
+
+func (r *Recorder) Command(w *simulation.World) sprite.Command {
+	cmd := r.Controller.Command(w)
+	r.Commands = append(r.Commands, cmd)
+	return cmd
+}
+
+// Replay flies the ship with recorded commands, one per tick. Once they run
+// out the ship is left alone.
+type Replay struct {
+	Commands []sprite.Command
+
+	next int
+}
+
+func NewReplay(commands []sprite.Command) *Replay {
+	return &Replay{Commands: commands}
+}
+
+func (r *Replay) Command(*simulation.World) sprite.Command {
+	if r.Done() {
+		return sprite.Command{}
+	}
+	cmd := r.Commands[r.next]
+	r.next++
+	return cmd
+}
+
+// Done reports whether every recorded command has been replayed.
+func (r *Replay) Done() bool {
+	return r.next >= len(r.Commands)
+}
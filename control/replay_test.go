@@ -0,0 +1,47 @@
+package control_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/control"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+func TestReplay(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	commands := []sprite.Command{{Thrust: true}, {}, {Fire: true}}
+	r := control.NewReplay(commands)
+
+	for _, want := range commands {
+		assert.False(r.Done())
+		assert.Equal(want, r.Command(w))
+	}
+	assert.True(r.Done())
+	assert.Equal(sprite.Command{}, r.Command(w), "the ship is left alone after the recording")
+}
+
+func TestRecorderReplaysTheSameGame(t *testing.T) {
+	assert := assert.New(t)
+	rules, _ := simulation.RulesForMode(simulation.ModeClassic)
+	played := simulation.NewWorld(config.Default(), 3, rules)
+	rec := &control.Recorder{Controller: control.NewBot()}
+	for range 900 {
+		played.Step(rec.Command(played))
+	}
+	assert.Len(rec.Commands, 900)
+
+	replayed := simulation.NewWorld(config.Default(), 3, rules)
+	r := control.NewReplay(rec.Commands)
+	for !r.Done() {
+		replayed.Step(r.Command(replayed))
+	}
+	assert.Equal(played.Clock.Ticks(), replayed.Clock.Ticks())
+	assert.Equal(played.Score, replayed.Score)
+	assert.Equal(played.Player, replayed.Player)
+	assert.Equal(played.AsteroidCtrl.Asteroids, replayed.AsteroidCtrl.Asteroids)
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"asteroid/input"
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+var actionLabels = map[input.Action]string{
+	input.Thrust:      "Thrust",
+	input.Reverse:     "Reverse",
+	input.RotateLeft:  "Rotate Left",
+	input.RotateRight: "Rotate Right",
+	input.Fire:        "Fire",
+	input.Pause:       "Pause / Back",
+	input.Confirm:     "Confirm",
+	input.HighScores:  "High Scores",
+	input.QuickSave:   "Quick Save",
+	input.QuickLoad:   "Quick Load",
+}
+
+// noAction is the action being rebound while none is.
+const noAction input.Action = -1
+
+// controlsScene lists the keys bound to every action. Picking an action waits
+// for the next key pressed, which becomes its only key. Esc, or backing out on
+// a gamepad, cancels instead. Gamepads keep their fixed layout.
+type controlsScene struct {
+	noHooks
+
+	game *Game
+	menu *menu
+	// rebinding is the action waiting for its new key.
+	rebinding input.Action
+	keys      []ebiten.Key
+}
+
+func newControlsScene(g *Game) *controlsScene {
+	s := &controlsScene{game: g, rebinding: noAction}
+
+	var items []menuItem
+	for _, a := range input.Actions() {
+		items = append(items, menuItem{
+			Label: actionLabels[a],
+			Value: func() string {
+				if s.rebinding == a {
+					return "PRESS A KEY"
+				}
+				return g.bindings.Describe(a)
+			},
+			Action: func() { s.rebinding = a },
+		})
+	}
+	items = append(items,
+		menuItem{Label: "Reset to Defaults", Action: func() {
+			g.bindings = input.DefaultBindings()
+			g.saveControls()
+		}},
+		menuItem{Label: "Back", Action: g.scenes.Pop},
+	)
+
+	s.menu = &menu{Title: "CONTROLS", Items: items, Back: g.scenes.Pop}
+	return s
+}
+
+func (s *controlsScene) Update() error {
+	if s.rebinding == noAction {
+		s.menu.Update(s.game.pressed)
+		return nil
+	}
+
+	s.keys = inpututil.AppendJustPressedKeys(s.keys[:0])
+	switch {
+	case len(s.keys) > 0:
+		s.bind(s.keys[0])
+	case s.game.pressed.Has(input.Pause):
+		// backing out on a gamepad
+		s.rebinding = noAction
+	}
+	return nil
+}
+
+// bind makes k the key of the action being rebound and saves the bindings.
+func (s *controlsScene) bind(k ebiten.Key) {
+	a := s.rebinding
+	s.rebinding = noAction
+	if k == ebiten.KeyEscape {
+		return
+	}
+	if err := s.game.bindings.Bind(a, k); err != nil {
+		log.Printf("Can not rebind %s: %v", a, err)
+		return
+	}
+	s.game.saveControls()
+}
+
+func (s *controlsScene) Draw(screen *ebiten.Image) {
+	s.menu.Draw(screen, s.game.smallFont, s.game.hudFont)
+}
+
+// keyHint names the first key bound to a, for instructions on screen.
+func keyHint(b *input.Bindings, a input.Action) string {
+	keys := b.Keys(a)
+	if len(keys) == 0 {
+		return strings.ToUpper(actionLabels[a])
+	}
+	return input.KeyLabel(keys[0])
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"asteroid/input"
+	"path/filepath"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestControls opens the controls menu from the title screen.
+func newTestControls(g *Game) *controlsScene {
+	g.scenes.Push(newSettingsScene(g))
+	settings := g.scenes.Top().(*settingsScene)
+	settings.menu.press(input.Thrust)
+	settings.menu.press(input.Thrust)
+	settings.menu.press(input.Confirm) // Controls
+	return g.scenes.Top().(*controlsScene)
+}
+
+func TestControls_Rebind(t *testing.T) {
+	assert := assert.New(t)
+	store := input.NewStore(filepath.Join(t.TempDir(), "controls.json"))
+	g := newTestGame()
+	g.UseControls(store)
+	s := newTestControls(g)
+
+	s.menu.press(input.Confirm) // Thrust
+	assert.Equal(input.Thrust, s.rebinding)
+	assert.Equal("PRESS A KEY", s.menu.Items[0].Value())
+	s.bind(ebiten.KeyZ)
+	assert.Equal(noAction, s.rebinding)
+	assert.Equal([]ebiten.Key{ebiten.KeyZ}, g.bindings.Keys(input.Thrust))
+
+	loaded, err := store.Load()
+	assert.NoError(err)
+	assert.Equal(g.bindings, loaded, "rebinding saves the controls")
+
+	s.menu.press(input.Reverse)
+	s.menu.press(input.Confirm) // Reverse
+	s.bind(ebiten.KeyEscape)
+	assert.Equal(input.DefaultBindings().Keys(input.Reverse), g.bindings.Keys(input.Reverse), "Esc cancels")
+
+	s.menu.press(input.Thrust)
+	s.menu.press(input.Thrust) // wraps to Back
+	s.menu.press(input.Thrust)
+	s.menu.press(input.Confirm) // Reset to Defaults
+	assert.Equal(input.DefaultBindings(), g.bindings)
+	loaded, _ = store.Load()
+	assert.Equal(input.DefaultBindings(), loaded)
+}
+
+func TestControls_PlayUsesBindings(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	g.bindings.Bind(input.Fire, ebiten.KeyF)
+	p := newTestPlay(g)
+
+	p.world.Step(shipCommand(g.bindings.Actions([]ebiten.Key{ebiten.KeySpace})))
+	assert.Empty(p.world.BulletCtrl.Bullets)
+	p.world.Step(shipCommand(g.bindings.Actions([]ebiten.Key{ebiten.KeyF})))
+	assert.Len(p.world.BulletCtrl.Bullets, 1)
+}
+
+func TestKeyHint(t *testing.T) {
+	assert := assert.New(t)
+	b := input.DefaultBindings()
+	assert.Equal("ENTER", keyHint(b, input.Confirm))
+	b.Bind(input.Fire, ebiten.KeyH)
+	assert.Equal("HIGH SCORES", keyHint(b, input.HighScores), "an action without keys is named")
+}
@@ -2,37 +2,28 @@ package game
 
 import (
 	"asteroid/assets/fonts"
-	"asteroid/constant"
-	"asteroid/sprite"
-	"asteroid/utils"
+	"asteroid/config"
+	"asteroid/highscore"
+	"asteroid/input"
+	"asteroid/replay"
+	"asteroid/simulation"
 
 	"bytes"
 	"fmt"
-	"image"
 	"image/color"
 	"log"
-	"slices"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
-)
-
-// Game States
-type gameState int
-
-const (
-	StatePlaying gameState = iota
-	StateGameOver
 )
 
 const (
-	gameOverFontSizeLarge = 48
-	gameOverFontSizeSmall = 24
+	largeFontSize = 48
+	smallFontSize = 24
 )
 
 var pressStart2pFont *text.GoTextFaceSource
@@ -45,201 +36,239 @@ func init() {
 	pressStart2pFont = s
 }
 
+// Game adapts the scenes of the game to the ebiten run loop and holds what is
+// shared between them.
 type Game struct {
-	player            sprite.Player
-	asteroidCtrl      sprite.AsteroidControl
-	bulletCtrl        sprite.BulletControl
-	keys              []ebiten.Key
-	state             gameState
-	gameOverFontLarge *text.GoTextFace
-	gameOverFontSmall *text.GoTextFace
+	scenes    *SceneManager
+	cfg       config.Config
+	seed      uint64
+	rules     simulation.Rules
+	largeFont *text.GoTextFace
+	smallFont *text.GoTextFace
+	hudFont   *text.GoTextFace
+
+	// store is nil when high scores are only kept for this session.
+	store  *highscore.Store
+	scores *highscore.Table
+
+	bindings *input.Bindings
+	gamepads *input.Gamepads
+	// controls is nil when changes to the bindings are not saved.
+	controls *input.Store
+	// held are the actions held and pressed the actions pressed since the
+	// last tick on the keyboard and the gamepads, read by the scenes in Update.
+	held    input.ActionSet
+	pressed input.ActionSet
+	keys    []ebiten.Key
+
+	// replays is nil when games are not recorded.
+	replays *replay.Store
+	// pool moves the entities of the worlds played, nil when they are moved
+	// on the goroutine of the game.
+	pool *simulation.Pool
+	// saves is the directory of the save slots, empty when games can not be
+	// saved. slot is the last slot used.
+	saves string
+	slot  int
+
+	// watcher is nil when the settings are not reloaded while running.
+	watcher *config.Watcher
+	// configErr is the problem with the last edit of the settings file, shown
+	// until the file is fixed.
+	configErr error
+
+	showFPS bool
+	// quit ends the run loop on the next update.
+	quit bool
 }
 
-func NewGame() *Game {
-	game := &Game{}
-	game.Reset()
-
-	return game
-}
-
-func (g *Game) Update() error {
-	g.keys = inpututil.AppendPressedKeys(g.keys[:0])
-
-	switch g.state {
-	case StatePlaying:
-		wg := &sync.WaitGroup{}
-		wg.Add(1)
-		go g.updatePlayer(wg)
-		wg.Add(1)
-		go g.updateAsteroids(wg)
-		wg.Add(1)
-		go g.updateBullets(wg)
-		wg.Wait()
-
-		// collision detection
-		if g.IsPlayerCollidedWithAsteroid() {
-			g.state = StateGameOver
-			return nil
-		}
-		g.CheckBulletCollidedWithAsteroid()
-
-		g.bulletCtrl.Clean()
-		g.asteroidCtrl.Clean()
+// NewGame creates a game with the settings of cfg played with rules whose
+// worlds are seeded with seed. A zero seed picks a new random seed on every
+// game. High scores are loaded from and saved to store, which may be nil. The
+// game opens on the title screen.
+func NewGame(cfg config.Config, seed uint64, rules simulation.Rules, store *highscore.Store) *Game {
+	game := &Game{
+		scenes:   NewSceneManager(),
+		cfg:      cfg,
+		seed:     seed,
+		rules:    rules,
+		store:    store,
+		bindings: input.DefaultBindings(),
+		gamepads: input.NewGamepads(),
+		slot:     1,
+		showFPS:  true,
+		largeFont: &text.GoTextFace{
+			Source: pressStart2pFont,
+			Size:   largeFontSize,
+		},
+		smallFont: &text.GoTextFace{
+			Source: pressStart2pFont,
+			Size:   smallFontSize,
+		},
+		hudFont: &text.GoTextFace{
+			Source: pressStart2pFont,
+			Size:   hudFontSize,
+		},
+	}
 
-		if slices.Contains(g.keys, ebiten.KeySpace) {
-			g.Fire()
-		}
-	case StateGameOver:
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-			g.Reset()
+	game.scores = highscore.NewTable(highscore.DefaultSize)
+	if store != nil {
+		scores, err := store.Load()
+		if err != nil {
+			log.Printf("load high scores error: %v", err)
+		} else {
+			game.scores = scores
 		}
 	}
+	game.showTitle()
 
-	return nil
+	return game
 }
 
-func (g *Game) Draw(screen *ebiten.Image) {
-	ebitenutil.DebugPrint(screen, fmt.Sprintf("%.2f, %.2f", g.player.Center.X, g.player.Center.Y))
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("FPS: %.2f", ebiten.ActualFPS()), constant.SCREEN_WIDTH-70, 10)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("TPS: %.2f", ebiten.ActualTPS()), constant.SCREEN_WIDTH-70, 0)
+// startGame fades into a new game.
+func (g *Game) startGame() {
+	g.scenes.Switch(newPlayScene(g, g.seed))
+}
 
-	// Always draw game elements
-	g.player.Draw(screen)
-	g.asteroidCtrl.Draw(screen)
-	g.bulletCtrl.Draw(screen)
+// showTitle fades back to the title screen.
+func (g *Game) showTitle() {
+	g.scenes.Switch(newTitleScene(g))
+}
 
-	// Draw Game Over overlay if needed
-	if g.state == StateGameOver {
-		g.drawGameOverOverlay(screen)
+// UseControls loads the key bindings from store and saves them there when
+// they are changed in the controls menu.
+func (g *Game) UseControls(store *input.Store) {
+	bindings, err := store.Load()
+	if err != nil {
+		log.Printf("load controls error: %v", err)
+		return
 	}
+	g.bindings = bindings
+	g.controls = store
 }
 
-func (g *Game) drawGameOverOverlay(screen *ebiten.Image) {
-	overlayWidth := float64(constant.SCREEN_WIDTH) * 0.6
-	overlayHeight := float64(constant.SCREEN_HEIGHT) * 0.6
-	x := (float64(constant.SCREEN_WIDTH) - overlayWidth) / 2
-	y := (float64(constant.SCREEN_HEIGHT) - overlayHeight) / 2
-
-	bgColor := color.RGBA{R: 20, G: 20, B: 20, A: 200}
-	vector.DrawFilledRect(screen, float32(x), float32(y), float32(overlayWidth), float32(overlayHeight), bgColor, true)
-
-	gameOverText := "GAME OVER"
-	restartText := "Press Enter to Restart"
-	textColor := color.White
-	restartTextColor := color.Gray{Y: 180}
-
-	wl, hl := text.Measure(gameOverText, g.gameOverFontLarge, g.gameOverFontLarge.Metrics().CapHeight)
-	ws, _ := text.Measure(restartText, g.gameOverFontSmall, g.gameOverFontSmall.Metrics().CapHeight)
-
-	gameOverX := x + (overlayWidth-wl)/2
-	gameOverY := y + overlayHeight*0.4
-
-	restartX := x + (overlayWidth-ws)/2
-	restartY := gameOverY + hl + overlayHeight*0.1
-
-	// Draw text
-	gameOverTextOp := &text.DrawOptions{}
-	gameOverTextOp.GeoM.Translate(gameOverX, gameOverY)
-	gameOverTextOp.ColorScale.ScaleWithColor(textColor)
-	text.Draw(screen, gameOverText, g.gameOverFontLarge, gameOverTextOp)
-
-	restartTextOp := &text.DrawOptions{}
-	restartTextOp.GeoM.Translate(restartX, restartY)
-	restartTextOp.ColorScale.ScaleWithColor(restartTextColor)
-	text.Draw(screen, restartText, g.gameOverFontSmall, restartTextOp)
+// saveControls persists the key bindings, if there is a store for them.
+func (g *Game) saveControls() {
+	if g.controls == nil {
+		return
+	}
+	if err := g.controls.Save(g.bindings); err != nil {
+		log.Printf("save controls error: %v", err)
+	}
 }
 
-func (g *Game) updatePlayer(wg *sync.WaitGroup) {
-	defer wg.Done()
-	g.player.Update(g.keys)
+// UsePool moves the entities of every world played, or played back, on the
+// workers of pool.
+func (g *Game) UsePool(pool *simulation.Pool) {
+	g.pool = pool
 }
 
-func (g *Game) updateAsteroids(wg *sync.WaitGroup) {
-	defer wg.Done()
-	g.asteroidCtrl.Update()
+// UseReplays saves the recording of every game played to store, to be played
+// back with PlayReplay.
+func (g *Game) UseReplays(store *replay.Store) {
+	g.replays = store
 }
 
-func (g *Game) updateBullets(wg *sync.WaitGroup) {
-	defer wg.Done()
-	g.bulletCtrl.Update()
+// saveReplay saves the recording of the game being played, if any.
+func (g *Game) saveReplay() {
+	for _, s := range g.scenes.stack {
+		if p, ok := s.(*playScene); ok {
+			p.saveReplay()
+		}
+	}
 }
 
-func (g *Game) Fire() {
-	bullet, err := g.player.Fire()
+// PlayReplay fades into the playback of rec.
+func (g *Game) PlayReplay(rec *replay.Recording) error {
+	s, err := newReplayScene(g, rec)
 	if err != nil {
-		if err == sprite.ErrGunNotReady {
-			return
-		}
-		log.Fatal(err)
+		return err
 	}
-	g.bulletCtrl.AddBullet(bullet)
+	g.scenes.Switch(s)
+	return nil
 }
 
-func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return constant.SCREEN_WIDTH, constant.SCREEN_HEIGHT
+// WatchConfig applies the edits w notices to the game being played and to
+// the games started after it.
+func (g *Game) WatchConfig(w *config.Watcher) {
+	g.watcher = w
 }
 
-func (g *Game) Reset() {
-	center := utils.Vector2{
-		X: constant.SCREEN_WIDTH / 2,
-		Y: constant.SCREEN_HEIGHT / 2,
+// reloadConfig applies the settings file if it changed. Invalid settings are
+// reported on screen and the game carries on with the previous ones.
+func (g *Game) reloadConfig() {
+	if g.watcher == nil {
+		return
+	}
+	cfg, changed, err := g.watcher.Poll(time.Now())
+	if !changed {
+		return
 	}
-	bounds := image.Rect(0, 0, constant.SCREEN_WIDTH, constant.SCREEN_HEIGHT)
-	rate, err := time.ParseDuration(constant.PLAYER_FIRE_RATE)
 	if err != nil {
-		log.Fatal(err)
+		log.Printf("reload settings error: %v", err)
+		g.configErr = err
+		return
 	}
-	gun := sprite.GunConfig{
-		Radius:    constant.BULLET_RADIUS,
-		Speed:     constant.BULLET_SPEED,
-		RateLimit: rate,
+
+	g.configErr = nil
+	// the window keeps its size
+	cfg.Screen = g.cfg.Screen
+	g.cfg = cfg
+	for _, s := range g.scenes.stack {
+		if p, ok := s.(*playScene); ok {
+			p.applyConfig(cfg)
+		}
 	}
-	player := sprite.NewPlayer(center, constant.PLAYER_RADUIS, bounds, constant.PLAYER_MOVE_SPEED, constant.PLAYER_ROTATION_SPEED, gun)
-	asteroidCtrl := sprite.NewAsteroidControl(
-		constant.ASTEROID_MIN_RADIUS,
-		constant.ASTEROID_KINDS,
-		bounds,
-		constant.ASTEROID_SPAWN_RATE,
-	)
+	log.Printf("Reloaded settings from %s", g.watcher.Source.Path)
+}
 
-	g.player = *player
-	g.asteroidCtrl = *asteroidCtrl
-	g.bulletCtrl = sprite.BulletControl{Bounds: bounds}
-	g.state = StatePlaying
+func (g *Game) Update() error {
+	g.reloadConfig()
+	g.held, g.pressed = g.gamepads.Update()
+	g.keys = inpututil.AppendPressedKeys(g.keys[:0])
+	g.held |= g.bindings.Actions(g.keys)
+	g.keys = inpututil.AppendJustPressedKeys(g.keys[:0])
+	g.pressed |= g.bindings.Actions(g.keys)
 
-	g.gameOverFontLarge = &text.GoTextFace{
-		Source: pressStart2pFont,
-		Size:   gameOverFontSizeLarge,
+	if err := g.scenes.Update(); err != nil {
+		return err
 	}
-
-	g.gameOverFontSmall = &text.GoTextFace{
-		Source: pressStart2pFont,
-		Size:   gameOverFontSizeSmall,
+	if g.quit {
+		return ebiten.Termination
 	}
+	return nil
 }
 
-func (g *Game) IsPlayerCollidedWithAsteroid() bool {
-	for _, a := range g.asteroidCtrl.Asteroids {
-		if g.player.IsCollided(a) {
-			log.Printf("Player(%.2f, %.2f) collided with Asteroid(%.2f, %.2f)", g.player.Center.X, g.player.Center.Y, a.Center.X, a.Center.Y)
-			return true
-		}
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.scenes.Draw(screen)
+
+	if g.showFPS {
+		x := screen.Bounds().Dx() - 70
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("FPS: %.2f", ebiten.ActualFPS()), x, 10)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("TPS: %.2f", ebiten.ActualTPS()), x, 0)
+	}
+	if g.configErr != nil {
+		g.drawConfigError(screen)
 	}
-	return false
 }
 
-func (g *Game) CheckBulletCollidedWithAsteroid() {
-	for i, b := range g.bulletCtrl.Bullets {
-		for j, a := range g.asteroidCtrl.Asteroids {
-			if b.IsDestoryed() || a.IsDestoryed() {
-				continue
-			}
+// configErrorColor is the color of invalid settings.
+var configErrorColor = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+
+// drawConfigError lists the problems of the settings file at the bottom left
+// of the screen.
+func (g *Game) drawConfigError(screen *ebiten.Image) {
+	msg := "SETTINGS NOT APPLIED\n" + g.configErr.Error()
+	lineSpacing := hudFontSize * 1.5
+	lines := strings.Count(msg, "\n") + 1
+
+	op := &text.DrawOptions{}
+	op.LineSpacing = lineSpacing
+	op.GeoM.Translate(hudMargin, float64(screen.Bounds().Dy())-hudMargin-float64(lines)*lineSpacing)
+	op.ColorScale.ScaleWithColor(configErrorColor)
+	text.Draw(screen, msg, g.hudFont, op)
+}
 
-			if b.IsCollided(a) {
-				log.Printf("Bullet(%.2f, %.2f) collided with Asteroid(%.2f, %.2f)", b.Center.X, b.Center.Y, a.Center.X, a.Center.Y)
-				g.bulletCtrl.HitBullet(i)
-				g.asteroidCtrl.HitAsteroid(j)
-			}
-		}
-	}
+func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return g.cfg.Screen.Width, g.cfg.Screen.Height
 }
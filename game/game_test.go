@@ -1,59 +1,167 @@
 package game
 
 import (
+	"asteroid/config"
+	"asteroid/input"
+	"asteroid/simulation"
 	"asteroid/sprite"
 	"asteroid/utils"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/stretchr/testify/assert"
 )
 
 func newTestGame() *Game {
-	game := &Game{}
-	game.Reset()
+	rules, _ := simulation.RulesForMode(simulation.ModeArcade)
+	game := NewGame(config.Default(), 1, rules, nil)
+	settle(game)
 	return game
 }
 
-func TestGameState_PlayingToGameOverOnCollision(t *testing.T) {
+// settle updates g until any transition between scenes is over.
+func settle(g *Game) {
+	for g.scenes.Transitioning() {
+		g.Update()
+	}
+}
+
+// newTestPlay starts a game on g and returns its scene.
+func newTestPlay(g *Game) *playScene {
+	g.startGame()
+	settle(g)
+	return g.scenes.Top().(*playScene)
+}
+
+func TestGame_OpensOnTitle(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	assert.IsType(&titleScene{}, g.scenes.Top())
+}
+
+func TestGame_StartFromTitle(t *testing.T) {
 	assert := assert.New(t)
 	g := newTestGame()
-	assert.Equal(StatePlaying, g.state, "Initial state should be StatePlaying")
+
+	g.scenes.Top().(*titleScene).menu.press(input.Confirm) // Start
+	assert.True(g.scenes.Transitioning())
+	settle(g)
+
+	p, ok := g.scenes.Top().(*playScene)
+	if assert.True(ok, "Start should switch to a game") {
+		assert.Equal(uint64(1), p.world.Seed)
+		assert.Len(g.scenes.stack, 1, "the title is replaced by the game")
+	}
+}
+
+func TestGame_PlayingToGameOverOnCollision(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+	p.world.Lives = 1
 
 	// Place an asteroid directly on the player
-	playerPos := g.player.Center
-	g.asteroidCtrl.AddAsteroid(
+	playerPos := p.world.Player.Center
+	p.world.AsteroidCtrl.AddAsteroid(
 		sprite.NewAsteroid(playerPos, 10, 0, *utils.NewVector2(1, 0)),
 	)
 
-	// Update should detect collision and change state
+	// Update should detect collision and show the game-over screen
 	err := g.Update()
 	assert.NoError(err, "Update should not return an error")
 
-	assert.Equal(StateGameOver, g.state, "State should be StateGameOver after collision")
+	assert.IsType(&gameOverScene{}, g.scenes.Top(), "Game over should be shown after collision")
+	assert.Same(p, g.scenes.stack[0], "The finished game stays below the game-over screen")
+}
+
+func TestGame_RestartStartsNewWorld(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+	p.world.Score = 1000
+	g.scenes.Push(newGameOverScene(g))
+
+	g.startGame()
+	settle(g)
+	restarted := g.scenes.Top().(*playScene)
+	assert.NotSame(p, restarted)
+	assert.Equal(0, restarted.world.Score, "Score should be zero after a restart")
+	assert.Len(g.scenes.stack, 1)
 }
 
-func TestGameState_ResetSetsStateToPlaying(t *testing.T) {
+func TestGame_UsePool(t *testing.T) {
 	assert := assert.New(t)
+	pool := simulation.NewPool(2)
+	defer pool.Close()
 	g := newTestGame()
-	g.state = StateGameOver
-	g.Reset()
+	assert.Nil(newTestPlay(g).world.Pool)
 
-	assert.Equal(StatePlaying, g.state, "State should be StatePlaying after Reset")
+	g.UsePool(pool)
+	p := newTestPlay(g)
+	assert.Same(pool, p.world.Pool)
+	for range 10 {
+		p.world.Step(sprite.Command{Fire: true})
+	}
+	g.UseSaves(t.TempDir())
+	p.saveSlot(1)
+	p.loadSlot(1)
+	assert.Same(pool, p.world.Pool, "a loaded game keeps the pool")
 }
 
-func TestGameState_UpdatesPausedOnGameOver(t *testing.T) {
+func TestGame_UpdatesPausedOnGameOver(t *testing.T) {
 	assert := assert.New(t)
 	g := newTestGame()
+	p := newTestPlay(g)
+	g.scenes.Push(newGameOverScene(g))
 
-	initialPlayerPos := g.player.Center
-	g.state = StateGameOver
-	g.keys = []ebiten.Key{ebiten.KeyArrowUp}
+	initialPlayerPos := p.world.Player.Center
 
 	for i := 0; i < 10; i++ {
 		err := g.Update()
-		assert.NoError(err, "Update should not return an error during GameOver state")
+		assert.NoError(err, "Update should not return an error during game over")
+	}
+
+	assert.Equal(initialPlayerPos, p.world.Player.Center, "Player position should remain unchanged on game over")
+}
+
+func TestGame_Quit(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+
+	m := g.scenes.Top().(*titleScene).menu
+	m.press(input.Thrust)
+	m.press(input.Confirm) // Quit
+	assert.ErrorIs(g.Update(), ebiten.Termination)
+}
+
+func TestGame_ReloadConfig(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	edit := func(data string, age time.Duration) {
+		assert.NoError(os.WriteFile(path, []byte(data), 0o644))
+		mod := time.Now().Add(-age)
+		assert.NoError(os.Chtimes(path, mod, mod))
 	}
+	edit("player:\n  move_speed: 250\n", time.Hour)
+
+	g := newTestGame()
+	g.WatchConfig(config.NewWatcher(&config.Source{Path: path, Required: true}, 0))
+	p := newTestPlay(g)
+
+	edit("player:\n  move_speed: 300\n", time.Minute)
+	assert.NoError(g.Update())
+	assert.Nil(g.configErr)
+	assert.Equal(300.0, p.world.Player.Speed, "the game being played picks up the edit")
+	assert.Equal(300.0, g.cfg.Player.MoveSpeed)
+
+	edit("player:\n  move_speed: nope\n", 0)
+	assert.NoError(g.Update(), "an invalid edit does not end the game")
+	assert.Error(g.configErr)
+	assert.Equal(300.0, p.world.Player.Speed)
 
-	assert.Equal(initialPlayerPos, g.player.Center, "Player position should remain unchanged in StateGameOver")
+	screen := ebiten.NewImage(g.cfg.Screen.Width, g.cfg.Screen.Height)
+	g.Draw(screen)
 }
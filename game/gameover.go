@@ -0,0 +1,48 @@
+package game
+
+import (
+	"asteroid/input"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// gameOverScene is pushed over the playScene of a finished game.
+type gameOverScene struct {
+	noHooks
+
+	game *Game
+}
+
+func newGameOverScene(g *Game) *gameOverScene {
+	return &gameOverScene{game: g}
+}
+
+func (s *gameOverScene) Update() error {
+	switch {
+	case s.game.pressed.Has(input.Confirm):
+		s.game.startGame()
+	case s.game.pressed.Has(input.HighScores):
+		s.game.scenes.Push(newHighScoresScene(s.game, -1))
+	case s.game.pressed.Has(input.Pause):
+		s.game.showTitle()
+	}
+	return nil
+}
+
+func (s *gameOverScene) Draw(screen *ebiten.Image) {
+	large, small := s.game.largeFont, s.game.smallFont
+	x, y, w, h := drawPanel(screen)
+	cx := x + w/2
+
+	_, hl := text.Measure("GAME OVER", large, large.Metrics().CapHeight)
+	gameOverY := y + h*0.3
+	restartY := gameOverY + hl + h*0.1
+
+	drawCentered(screen, "GAME OVER", large, cx, gameOverY, color.White)
+	bindings := s.game.bindings
+	drawCentered(screen, "Press "+keyHint(bindings, input.Confirm)+" to Restart", small, cx, restartY, color.Gray{Y: 180})
+	drawCentered(screen, "Press "+keyHint(bindings, input.HighScores)+" for High Scores", small, cx, restartY+h*0.1, color.Gray{Y: 180})
+	drawCentered(screen, "Press "+keyHint(bindings, input.Pause)+" for Title", small, cx, restartY+h*0.2, color.Gray{Y: 180})
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"asteroid/highscore"
+	"asteroid/input"
+	"asteroid/simulation"
+
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// defaultName is recorded when the player submits an empty name.
+const defaultName = "PLAYER"
+
+// maxNameLength is the most characters a name on the table can have.
+const maxNameLength = 10
+
+// enterNameScene asks for the name of a finished game which made the
+// high-score table.
+type enterNameScene struct {
+	noHooks
+
+	game  *Game
+	world *simulation.World
+	name  string
+	chars []rune
+}
+
+func newEnterNameScene(g *Game, world *simulation.World) *enterNameScene {
+	return &enterNameScene{game: g, world: world}
+}
+
+func (s *enterNameScene) Update() error {
+	s.chars = ebiten.AppendInputChars(s.chars[:0])
+	s.name = appendName(s.name, s.chars)
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.name) > 0 {
+		s.name = s.name[:len(s.name)-1]
+	}
+	// Confirm submits from the keyboard or a gamepad, a player without a
+	// keyboard is recorded under the default name
+	if s.game.pressed.Has(input.Confirm) {
+		s.submit()
+	}
+	return nil
+}
+
+// appendName adds the printable ASCII characters of chars to name, in upper
+// case, up to the maximum name length.
+func appendName(name string, chars []rune) string {
+	for _, r := range chars {
+		if len(name) >= maxNameLength {
+			break
+		}
+		if r < ' ' || r > '~' {
+			continue
+		}
+		name += string(unicode.ToUpper(r))
+	}
+	return name
+}
+
+// submit records the finished game on the high-score table, saves the table
+// and shows it over the game-over screen.
+func (s *enterNameScene) submit() {
+	g := s.game
+	name := strings.TrimSpace(s.name)
+	if name == "" {
+		name = defaultName
+	}
+	rank := g.scores.Add(highscore.Entry{
+		Name:  name,
+		Score: s.world.Score,
+		Date:  time.Now(),
+		Seed:  s.world.Seed,
+		Mode:  s.world.Rules.Mode,
+	})
+	if g.store != nil {
+		if err := g.store.Save(g.scores); err != nil {
+			log.Printf("save high scores error: %v", err)
+		}
+	}
+
+	g.scenes.Pop()
+	g.scenes.Push(newGameOverScene(g))
+	g.scenes.Push(newHighScoresScene(g, rank))
+}
+
+func (s *enterNameScene) Draw(screen *ebiten.Image) {
+	large, small := s.game.largeFont, s.game.smallFont
+	x, y, w, h := drawPanel(screen)
+	cx := x + w/2
+
+	drawCentered(screen, "NEW HIGH SCORE", large, cx, y+h*0.15, color.White)
+	drawCentered(screen, fmt.Sprintf("%06d", s.world.Score), small, cx, y+h*0.4, color.White)
+	drawCentered(screen, "Enter Your Name", small, cx, y+h*0.55, color.Gray{Y: 180})
+
+	// pad the name, so it does not move while typing
+	name := s.name + "_"
+	name += strings.Repeat(" ", maxNameLength+1-len(name))
+	drawCentered(screen, name, small, cx, y+h*0.7, color.White)
+
+	hint := fmt.Sprintf("%s to Save", keyHint(s.game.bindings, input.Confirm))
+	drawCentered(screen, hint, s.game.hudFont, cx, y+h*0.9, color.Gray{Y: 180})
+}
+
+// highScoresScene shows the high-score table, highlighting the row at rank.
+type highScoresScene struct {
+	noHooks
+
+	game *Game
+	rank int
+}
+
+// newHighScoresScene creates the table screen, rank is -1 to highlight no row.
+func newHighScoresScene(g *Game, rank int) *highScoresScene {
+	return &highScoresScene{game: g, rank: rank}
+}
+
+func (s *highScoresScene) Update() error {
+	switch {
+	case s.game.pressed.Has(input.Confirm):
+		s.game.startGame()
+	case s.game.pressed.Has(input.Pause):
+		s.game.scenes.Pop()
+	}
+	return nil
+}
+
+func (s *highScoresScene) Draw(screen *ebiten.Image) {
+	g := s.game
+	x, y, w, h := drawPanel(screen)
+	cx := x + w/2
+
+	drawCentered(screen, "HIGH SCORES", g.smallFont, cx, y+h*0.06, color.White)
+
+	rowY := y + h*0.18
+	if len(g.scores.Entries) == 0 {
+		drawCentered(screen, "No scores yet", g.hudFont, cx, rowY, color.Gray{Y: 180})
+	}
+	for i, e := range g.scores.Entries {
+		row := fmt.Sprintf("%2d. %-*s %06d %-7s %s", i+1, maxNameLength, e.Name, e.Score, e.Mode, e.Date.Format(time.DateOnly))
+		var clr color.Color = color.White
+		if i == s.rank {
+			clr = menuHighlightColor
+		}
+		drawCentered(screen, row, g.hudFont, cx, rowY, clr)
+		rowY += hudFontSize * 1.5
+	}
+
+	hint := fmt.Sprintf("%s to Play  %s to Go Back", keyHint(g.bindings, input.Confirm), keyHint(g.bindings, input.Pause))
+	drawCentered(screen, hint, g.hudFont, cx, y+h*0.9, color.Gray{Y: 180})
+}
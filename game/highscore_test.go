@@ -0,0 +1,98 @@
+package game
+
+import (
+	"asteroid/config"
+	"asteroid/highscore"
+	"asteroid/input"
+	"asteroid/simulation"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_EndGameAsksForNameOnHighScore(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+
+	p.endGame()
+	assert.IsType(&gameOverScene{}, g.scenes.Top(), "An empty game should not make the table")
+	g.scenes.Pop()
+
+	p.world.Score = 500
+	p.endGame()
+	assert.IsType(&enterNameScene{}, g.scenes.Top())
+}
+
+func TestGame_SubmitNameSavesHighScore(t *testing.T) {
+	assert := assert.New(t)
+	rules, _ := simulation.RulesForMode(simulation.ModeClassic)
+	store := highscore.NewStore(filepath.Join(t.TempDir(), "scores.json"), 5)
+	g := NewGame(config.Default(), 3, rules, store)
+	p := newTestPlay(g)
+
+	p.world.Score = 500
+	p.endGame()
+	s := g.scenes.Top().(*enterNameScene)
+	s.name = "ace "
+	s.submit()
+
+	scores, ok := g.scenes.Top().(*highScoresScene)
+	if assert.True(ok, "the table is shown after submitting") {
+		assert.Equal(0, scores.rank)
+	}
+	g.scenes.Pop()
+	assert.IsType(&gameOverScene{}, g.scenes.Top(), "going back from the table leads to game over")
+
+	loaded, err := store.Load()
+	assert.NoError(err)
+	if assert.Len(loaded.Entries, 1) {
+		e := loaded.Entries[0]
+		assert.Equal("ace", e.Name)
+		assert.Equal(500, e.Score)
+		assert.Equal(uint64(3), e.Seed)
+		assert.Equal(simulation.ModeClassic, e.Mode)
+		assert.False(e.Date.IsZero())
+	}
+
+	// the table is loaded again by a new game
+	g = NewGame(config.Default(), 3, rules, store)
+	assert.Len(g.scores.Entries, 1)
+}
+
+func TestGame_SubmitEmptyName(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+	p.world.Score = 100
+	p.endGame()
+	g.scenes.Top().(*enterNameScene).submit()
+	assert.Equal(defaultName, g.scores.Entries[0].Name)
+}
+
+func TestGame_ConfirmSubmitsName(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+	p.world.Score = 100
+	p.endGame()
+
+	assert.NoError(g.scenes.Top().Update())
+	assert.IsType(&enterNameScene{}, g.scenes.Top(), "the name is not submitted without Confirm")
+
+	g.pressed = input.NewActionSet(input.Confirm)
+	assert.NoError(g.scenes.Top().Update())
+	assert.IsType(&highScoresScene{}, g.scenes.Top())
+	if assert.Len(g.scores.Entries, 1) {
+		assert.Equal(defaultName, g.scores.Entries[0].Name)
+	}
+}
+
+func TestAppendName(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("AB C", appendName("", []rune("ab c")))
+	assert.Equal("AB", appendName("A", []rune("\tb\n")), "control characters are dropped")
+	assert.Equal("ABCDEFGHIJ", appendName("ABCDEFGH", []rune("ijklm")), "names are capped")
+	assert.Equal("A", appendName("A", []rune("é")), "only ASCII is drawn by the font")
+}
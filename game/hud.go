@@ -0,0 +1,35 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	hudFontSize = 16
+	hudMargin   = 16
+)
+
+// drawHUD draws the score, the lives left and the wave of the current game at
+// the top left of the screen, and the banner of the next wave between waves.
+func (p *playScene) drawHUD(screen *ebiten.Image) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(hudMargin, hudMargin)
+	op.ColorScale.ScaleWithColor(color.White)
+	text.Draw(screen, fmt.Sprintf("SCORE %06d", p.world.Score), p.game.hudFont, op)
+
+	op.GeoM.Translate(0, hudFontSize+hudMargin/2)
+	text.Draw(screen, fmt.Sprintf("LIVES %d", p.world.Lives), p.game.hudFont, op)
+
+	op.GeoM.Translate(0, hudFontSize+hudMargin/2)
+	text.Draw(screen, fmt.Sprintf("WAVE  %d", p.world.Wave), p.game.hudFont, op)
+
+	if n, ok := p.world.UpcomingWave(); ok {
+		bounds := screen.Bounds()
+		cx, cy := float64(bounds.Dx())/2, float64(bounds.Dy())/3
+		drawCentered(screen, fmt.Sprintf("WAVE %d", n), p.game.largeFont, cx, cy, color.White)
+	}
+}
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"asteroid/input"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+// shipCommand returns the command of the ship actions held in actions.
+func shipCommand(actions input.ActionSet) sprite.Command {
+	return sprite.Command{
+		Thrust:      actions.Has(input.Thrust),
+		Reverse:     actions.Has(input.Reverse),
+		RotateLeft:  actions.Has(input.RotateLeft),
+		RotateRight: actions.Has(input.RotateRight),
+		Fire:        actions.Has(input.Fire),
+	}
+}
+
+// localShip flies the ship with the keyboard and the gamepads of this machine.
+type localShip struct {
+	game *Game
+}
+
+func (l localShip) Command(*simulation.World) sprite.Command {
+	return shipCommand(l.game.held)
+}
@@ -0,0 +1,31 @@
+package game
+
+import (
+	"asteroid/input"
+	"asteroid/sprite"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(sprite.Command{}, shipCommand(0))
+	assert.Equal(sprite.Command{Thrust: true, RotateRight: true, Fire: true},
+		shipCommand(input.NewActionSet(input.Thrust, input.RotateRight, input.Fire, input.Pause)))
+	assert.Equal(sprite.Command{Reverse: true, RotateLeft: true},
+		shipCommand(input.NewActionSet(input.Reverse, input.RotateLeft)))
+}
+
+func TestLocalShip(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+	l := localShip{game: g}
+
+	g.held = input.NewActionSet(input.Fire)
+	assert.Equal(sprite.Command{Fire: true}, l.Command(p.world))
+	g.held = input.NewActionSet(input.Thrust)
+	assert.Equal(sprite.Command{Thrust: true}, l.Command(p.world))
+}
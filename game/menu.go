@@ -0,0 +1,106 @@
+package game
+
+import (
+	"asteroid/input"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var menuHighlightColor = color.RGBA{R: 255, G: 220, B: 0, A: 255}
+
+type menuItem struct {
+	Label string
+	// Value returns the current setting shown next to the label, if any.
+	Value  func() string
+	Action func()
+}
+
+// menu is a vertical list of items picked with the ship controls: thrust and
+// reverse move up and down the list, confirm or fire pick an item and pause
+// goes back.
+type menu struct {
+	Title string
+	Items []menuItem
+	// Back is called when the menu is dismissed, it may be nil.
+	Back     func()
+	selected int
+}
+
+// Update handles the actions pressed this tick.
+func (m *menu) Update(pressed input.ActionSet) {
+	if pressed.Has(input.Confirm) {
+		// a single button may both confirm and fire
+		pressed = pressed.Without(input.Fire)
+	}
+	for _, a := range input.Actions() {
+		if pressed.Has(a) {
+			m.press(a)
+		}
+	}
+}
+
+// press handles a single action.
+func (m *menu) press(a input.Action) {
+	switch a {
+	case input.Thrust:
+		m.selected = (m.selected + len(m.Items) - 1) % len(m.Items)
+	case input.Reverse:
+		m.selected = (m.selected + 1) % len(m.Items)
+	case input.Confirm, input.Fire:
+		if a := m.Items[m.selected].Action; a != nil {
+			a()
+		}
+	case input.Pause:
+		if m.Back != nil {
+			m.Back()
+		}
+	}
+}
+
+func (m *menu) Draw(screen *ebiten.Image, titleFace, itemFace *text.GoTextFace) {
+	x, y, w, h := drawPanel(screen)
+	cx := x + w/2
+
+	drawCentered(screen, m.Title, titleFace, cx, y+h*0.15, color.White)
+
+	rowY := y + h*0.4
+	for i, item := range m.Items {
+		label := item.Label
+		if item.Value != nil {
+			label += ": " + item.Value()
+		}
+		var clr color.Color = color.Gray{Y: 180}
+		if i == m.selected {
+			label = "> " + label + " <"
+			clr = menuHighlightColor
+		}
+		drawCentered(screen, label, itemFace, cx, rowY, clr)
+		rowY += itemFace.Size * 1.8
+	}
+}
+
+// drawPanel darkens the middle of the screen for an overlay and returns its
+// position and size.
+func drawPanel(screen *ebiten.Image) (x, y, w, h float64) {
+	bounds := screen.Bounds()
+	w = float64(bounds.Dx()) * 0.6
+	h = float64(bounds.Dy()) * 0.6
+	x = (float64(bounds.Dx()) - w) / 2
+	y = (float64(bounds.Dy()) - h) / 2
+
+	bgColor := color.RGBA{R: 20, G: 20, B: 20, A: 200}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), bgColor, true)
+	return x, y, w, h
+}
+
+// drawCentered draws s horizontally centered on cx, with its top at y.
+func drawCentered(screen *ebiten.Image, s string, face *text.GoTextFace, cx, y float64, clr color.Color) {
+	w, _ := text.Measure(s, face, face.Metrics().CapHeight)
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(cx-w/2, y)
+	op.ColorScale.ScaleWithColor(clr)
+	text.Draw(screen, s, face, op)
+}
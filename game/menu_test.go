@@ -0,0 +1,44 @@
+package game
+
+import (
+	"asteroid/input"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMenu_Press(t *testing.T) {
+	assert := assert.New(t)
+	var picked []string
+	back := false
+	m := &menu{
+		Items: []menuItem{
+			{Label: "A", Action: func() { picked = append(picked, "A") }},
+			{Label: "B"},
+			{Label: "C", Action: func() { picked = append(picked, "C") }},
+		},
+		Back: func() { back = true },
+	}
+
+	m.press(input.Confirm)
+	m.press(input.Thrust) // wraps to the last item
+	m.press(input.Fire)
+	m.press(input.Reverse)
+	m.press(input.Reverse)
+	m.press(input.Confirm) // B has no action
+	assert.Equal([]string{"A", "C"}, picked)
+	assert.Equal(1, m.selected)
+
+	m.press(input.Pause)
+	assert.True(back)
+}
+
+func TestMenu_ConfirmAndFirePickOnce(t *testing.T) {
+	assert := assert.New(t)
+	picked := 0
+	m := &menu{Items: []menuItem{{Label: "A", Action: func() { picked++ }}}}
+
+	// A on a gamepad is both
+	m.Update(input.NewActionSet(input.Confirm, input.Fire))
+	assert.Equal(1, picked)
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"asteroid/simulation"
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// pauseScene is pushed over a playScene. The world below is not stepped while
+// it is on top, so the fire cooldown and the spawn timers, which run on the
+// world clock, freeze too.
+type pauseScene struct {
+	noHooks
+
+	game *Game
+	menu *menu
+}
+
+func newPauseScene(g *Game) *pauseScene {
+	resume := g.scenes.Pop
+	items := []menuItem{
+		{Label: "Resume", Action: resume},
+		{Label: "Restart", Action: func() {
+			g.saveReplay()
+			g.startGame()
+		}},
+	}
+	if play, ok := g.scenes.Top().(*playScene); ok && g.saves != "" {
+		items = append(items,
+			menuItem{Label: "Save Game", Action: func() { g.scenes.Push(newSlotsScene(g, play, true)) }},
+			menuItem{Label: "Load Game", Action: func() { g.scenes.Push(newSlotsScene(g, play, false)) }},
+		)
+	}
+	items = append(items,
+		menuItem{Label: "Settings", Action: func() { g.scenes.Push(newSettingsScene(g)) }},
+		menuItem{Label: "Quit", Action: func() {
+			g.saveReplay()
+			g.quit = true
+		}},
+	)
+	return &pauseScene{
+		game: g,
+		menu: &menu{Title: "PAUSED", Items: items, Back: resume},
+	}
+}
+
+func (p *pauseScene) Update() error {
+	p.menu.Update(p.game.pressed)
+	return nil
+}
+
+func (p *pauseScene) Draw(screen *ebiten.Image) {
+	p.menu.Draw(screen, p.game.largeFont, p.game.smallFont)
+}
+
+// settingsScene is pushed over the title screen or the pause menu.
+type settingsScene struct {
+	noHooks
+
+	game *Game
+	menu *menu
+}
+
+func newSettingsScene(g *Game) *settingsScene {
+	return &settingsScene{
+		game: g,
+		menu: &menu{
+			Title: "SETTINGS",
+			Items: []menuItem{
+				{
+					Label:  "Mode",
+					Value:  func() string { return strings.ToUpper(g.rules.Mode) },
+					Action: g.toggleMode,
+				},
+				{
+					Label:  "Show FPS",
+					Value:  func() string { return onOff(g.showFPS) },
+					Action: func() { g.showFPS = !g.showFPS },
+				},
+				{Label: "Controls", Action: func() { g.scenes.Push(newControlsScene(g)) }},
+				{Label: "Back", Action: g.scenes.Pop},
+			},
+			Back: g.scenes.Pop,
+		},
+	}
+}
+
+func (s *settingsScene) Update() error {
+	s.menu.Update(s.game.pressed)
+	return nil
+}
+
+func (s *settingsScene) Draw(screen *ebiten.Image) {
+	s.menu.Draw(screen, s.game.largeFont, s.game.smallFont)
+}
+
+// toggleMode switches between the game modes. The new mode is played from
+// the next game on.
+func (g *Game) toggleMode() {
+	mode := simulation.ModeClassic
+	if g.rules.Mode == simulation.ModeClassic {
+		mode = simulation.ModeArcade
+	}
+	rules, err := simulation.RulesForMode(mode)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rules.Waves = g.rules.Waves
+	g.rules = rules
+}
+
+func onOff(b bool) string {
+	if b {
+		return "ON"
+	}
+	return "OFF"
+}
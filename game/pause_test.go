@@ -0,0 +1,88 @@
+package game
+
+import (
+	"asteroid/input"
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPause starts a game on g and pauses it.
+func newTestPause(g *Game) (*playScene, *pauseScene) {
+	p := newTestPlay(g)
+	pause := newPauseScene(g)
+	g.scenes.Push(pause)
+	return p, pause
+}
+
+func TestGame_PauseFreezesWorld(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p := newTestPlay(g)
+	p.world.Step(sprite.Command{Fire: true})
+
+	pause := newPauseScene(g)
+	g.scenes.Push(pause)
+	ticks := p.world.Clock.Ticks()
+	center := p.world.Player.Center
+	bullets := len(p.world.BulletCtrl.Bullets)
+	for range 60 {
+		assert.NoError(g.Update())
+	}
+	assert.Equal(ticks, p.world.Clock.Ticks(), "the world clock should not move while paused")
+	assert.Equal(center, p.world.Player.Center)
+	assert.Equal(bullets, len(p.world.BulletCtrl.Bullets))
+
+	pause.menu.press(input.Confirm) // Resume
+	assert.Same(p, g.scenes.Top())
+}
+
+func TestGame_PauseMenuRestart(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p, pause := newTestPause(g)
+	p.world.Score = 100
+
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Confirm) // Restart
+	settle(g)
+	assert.Len(g.scenes.stack, 1)
+	assert.Equal(0, g.scenes.Top().(*playScene).world.Score)
+}
+
+func TestGame_PauseMenuSettings(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	p, pause := newTestPause(g)
+
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Confirm) // Settings
+	settings, ok := g.scenes.Top().(*settingsScene)
+	assert.True(ok)
+
+	settings.menu.press(input.Confirm) // Mode
+	assert.Equal(simulation.ModeClassic, g.rules.Mode)
+	assert.Equal(simulation.ModeArcade, p.world.Rules.Mode, "the mode changes on the next game")
+	settings.menu.press(input.Reverse)
+	settings.menu.press(input.Confirm) // Show FPS
+	assert.False(g.showFPS)
+
+	settings.menu.press(input.Pause)
+	assert.Same(pause, g.scenes.Top())
+	pause.menu.press(input.Pause)
+	assert.Same(p, g.scenes.Top())
+}
+
+func TestGame_PauseMenuQuit(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	_, pause := newTestPause(g)
+
+	pause.menu.press(input.Thrust)
+	pause.menu.press(input.Confirm) // Quit
+	assert.ErrorIs(g.Update(), ebiten.Termination)
+}
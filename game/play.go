@@ -0,0 +1,114 @@
+package game
+
+import (
+	"asteroid/config"
+	"asteroid/control"
+	"asteroid/input"
+	"asteroid/render"
+	"asteroid/replay"
+	"asteroid/simulation"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// playScene runs a single game. It stays on the stack below the pause menu
+// and the game-over screens, so the frozen field is drawn behind them.
+type playScene struct {
+	noHooks
+
+	game  *Game
+	world *simulation.World
+	// ship flies the ship of the world.
+	ship control.ShipController
+	// rec is the recording of the game, nil once it has been saved.
+	rec      *replay.Recording
+	recorder *control.Recorder
+	// notice is shown over the game for noticeLeft more ticks.
+	notice     string
+	noticeLeft int
+}
+
+// newPlayScene starts a game seeded with seed, a zero seed picks a random one.
+func newPlayScene(g *Game, seed uint64) *playScene {
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	log.Printf("Starting game with seed %d", seed)
+
+	rec, rules := replay.NewRecording(g.cfg, seed, g.rules)
+	recorder := &control.Recorder{Controller: localShip{game: g}}
+	world := simulation.NewWorld(g.cfg, seed, rules)
+	world.Pool = g.pool
+	return &playScene{
+		game:     g,
+		world:    world,
+		ship:     recorder,
+		rec:      rec,
+		recorder: recorder,
+	}
+}
+
+func (p *playScene) Update() error {
+	if p.game.pressed.Has(input.Pause) {
+		p.game.scenes.Push(newPauseScene(p.game))
+		return nil
+	}
+	p.noticeLeft--
+	if p.game.saves != "" {
+		switch {
+		case p.game.pressed.Has(input.QuickSave):
+			p.saveSlot(p.game.slot)
+		case p.game.pressed.Has(input.QuickLoad):
+			p.loadSlot(p.game.slot)
+		}
+	}
+
+	p.world.Step(p.ship.Command(p.world))
+	if p.world.IsOver() {
+		p.endGame()
+	}
+	return nil
+}
+
+// applyConfig changes the settings of the game from the next tick on.
+func (p *playScene) applyConfig(cfg config.Config) {
+	p.world.ApplyConfig(cfg)
+	if p.rec != nil {
+		p.rec.ApplyConfig(p.world.Clock.Ticks(), cfg)
+	}
+}
+
+// saveReplay saves the recording of the game, if replays are kept. A game is
+// only saved once.
+func (p *playScene) saveReplay() {
+	if p.rec == nil || p.game.replays == nil {
+		return
+	}
+	p.rec.Commands = p.recorder.Commands
+	path, err := p.game.replays.Save(p.rec, time.Now())
+	p.rec = nil
+	if err != nil {
+		log.Printf("save replay error: %v", err)
+		return
+	}
+	log.Printf("Saved replay to %s", path)
+}
+
+// endGame asks for a name if the final score made the high-score table.
+func (p *playScene) endGame() {
+	p.saveReplay()
+	if p.game.scores.Qualifies(p.world.Score) {
+		p.game.scenes.Push(newEnterNameScene(p.game, p.world))
+		return
+	}
+	p.game.scenes.Push(newGameOverScene(p.game))
+}
+
+func (p *playScene) Draw(screen *ebiten.Image) {
+	render.World(screen, p.world)
+	p.drawHUD(screen)
+	p.drawNotice(screen)
+}
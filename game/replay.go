@@ -0,0 +1,105 @@
+package game
+
+import (
+	"asteroid/input"
+	"asteroid/replay"
+	"asteroid/sprite"
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// replaySpeeds are the speeds a replay is played at, in ticks per update.
+var replaySpeeds = []int{1, 2, 4, 8}
+
+// replayScene plays back a recorded game. Pause plays and pauses it, rotating
+// right and left fast-forwards and slows down, fire steps a single tick while
+// paused and confirm goes back to the title screen.
+type replayScene struct {
+	noHooks
+
+	game     *Game
+	playback *replay.Playback
+	// view draws the world being played back.
+	view   *playScene
+	paused bool
+	// speed is the index of the speed in replaySpeeds.
+	speed int
+}
+
+func newReplayScene(g *Game, rec *replay.Recording) (*replayScene, error) {
+	pb, err := replay.NewPlayback(rec)
+	if err != nil {
+		return nil, err
+	}
+	pb.World.Pool = g.pool
+	log.Printf("Playing back game with seed %d", rec.Seed)
+	return &replayScene{
+		game:     g,
+		playback: pb,
+		view:     &playScene{game: g, world: pb.World},
+	}, nil
+}
+
+func (s *replayScene) Update() error {
+	pressed := s.game.pressed
+	switch {
+	case pressed.Has(input.Confirm):
+		s.game.showTitle()
+		return nil
+	case pressed.Has(input.Pause):
+		s.paused = !s.paused
+	case pressed.Has(input.RotateRight):
+		s.speed = min(s.speed+1, len(replaySpeeds)-1)
+	case pressed.Has(input.RotateLeft):
+		s.speed = max(s.speed-1, 0)
+	}
+
+	if s.paused {
+		if pressed.Has(input.Fire) {
+			s.playback.Step()
+		}
+		return nil
+	}
+	for range replaySpeeds[s.speed] {
+		s.playback.Step()
+	}
+	return nil
+}
+
+// status describes where the playback is.
+func (s *replayScene) status() string {
+	tick, ticks := s.playback.Progress()
+	progress := formatTicks(tick) + " / " + formatTicks(ticks)
+	switch {
+	case s.playback.Done():
+		return "END OF REPLAY  " + progress
+	case s.paused:
+		return "PAUSED  " + progress
+	}
+	return fmt.Sprintf("REPLAY x%d  %s", replaySpeeds[s.speed], progress)
+}
+
+// formatTicks formats a number of ticks as minutes and seconds.
+func formatTicks(ticks int) string {
+	secs := ticks / sprite.TPS
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+func (s *replayScene) Draw(screen *ebiten.Image) {
+	s.view.Draw(screen)
+
+	bounds := screen.Bounds()
+	cx := float64(bounds.Dx()) / 2
+	bottom := float64(bounds.Dy()) - hudMargin
+	hint := fmt.Sprintf("%s PLAY/PAUSE  %s/%s SPEED  %s STEP  %s EXIT",
+		keyHint(s.game.bindings, input.Pause),
+		keyHint(s.game.bindings, input.RotateLeft),
+		keyHint(s.game.bindings, input.RotateRight),
+		keyHint(s.game.bindings, input.Fire),
+		keyHint(s.game.bindings, input.Confirm))
+	drawCentered(screen, hint, s.game.hudFont, cx, bottom-hudFontSize, color.Gray{Y: 180})
+	drawCentered(screen, s.status(), s.game.hudFont, cx, bottom-3*hudFontSize, color.White)
+}
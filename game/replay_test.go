@@ -0,0 +1,101 @@
+package game
+
+import (
+	"asteroid/input"
+	"asteroid/replay"
+	"asteroid/sprite"
+	"asteroid/utils"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestReplay records a game of ticks ticks on g, lost at the end to an
+// asteroid dropped on the ship by hand, and returns the saved recording.
+func newTestReplay(t *testing.T, g *Game, ticks int) *replay.Recording {
+	dir := t.TempDir()
+	g.UseReplays(replay.NewStore(dir, replay.DefaultKeep))
+	p := newTestPlay(g)
+	for range ticks - 1 {
+		assert.NoError(t, g.Update())
+	}
+	p.world.Lives = 1
+	p.world.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(p.world.Player.Center, 10, 0, *utils.NewVector2(1, 0)))
+	assert.NoError(t, g.Update())
+	assert.IsType(t, &gameOverScene{}, g.scenes.Top())
+
+	entries, _ := os.ReadDir(dir)
+	if !assert.Len(t, entries, 1, "the replay is saved once the game is over") {
+		t.FailNow()
+	}
+	rec, err := replay.Load(filepath.Join(dir, entries[0].Name()))
+	assert.NoError(t, err)
+	return rec
+}
+
+func TestGame_RecordsReplays(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	rec := newTestReplay(t, g, 90)
+	assert.Equal(uint64(1), rec.Seed)
+	assert.Equal(g.rules.Mode, rec.Mode)
+	assert.Len(rec.Commands, 90)
+
+	g.saveReplay()
+	entries, _ := os.ReadDir(g.replays.Dir)
+	assert.Len(entries, 1, "a game is only saved once")
+}
+
+func TestGame_QuitSavesReplay(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	g.UseReplays(replay.NewStore(t.TempDir(), replay.DefaultKeep))
+	_, pause := newTestPause(g)
+
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Confirm) // Quit
+	entries, _ := os.ReadDir(g.replays.Dir)
+	assert.Len(entries, 1)
+}
+
+func TestReplay_Playback(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	rec := newTestReplay(t, g, 90)
+
+	assert.NoError(g.PlayReplay(rec))
+	settle(g)
+	s := g.scenes.Top().(*replayScene)
+	assert.NoError(g.Update())
+	assert.Equal(uint64(1), s.playback.World.Clock.Ticks())
+
+	g.pressed = input.NewActionSet(input.RotateRight)
+	assert.NoError(s.Update())
+	assert.Equal(uint64(3), s.playback.World.Clock.Ticks(), "fast-forward plays two ticks an update")
+
+	g.pressed = input.NewActionSet(input.Pause)
+	assert.NoError(s.Update())
+	assert.Equal(uint64(3), s.playback.World.Clock.Ticks(), "paused")
+	assert.Contains(s.status(), "PAUSED")
+	g.pressed = input.NewActionSet(input.Fire)
+	assert.NoError(s.Update())
+	assert.Equal(uint64(4), s.playback.World.Clock.Ticks(), "a single tick is stepped")
+
+	g.pressed = input.NewActionSet(input.Pause)
+	for range 100 {
+		assert.NoError(s.Update())
+		g.pressed = 0
+	}
+	assert.True(s.playback.Done())
+	assert.Equal(uint64(90), s.playback.World.Clock.Ticks())
+	assert.Equal("END OF REPLAY  00:01 / 00:01", s.status())
+
+	g.pressed = input.NewActionSet(input.Confirm)
+	assert.NoError(s.Update())
+	settle(g)
+	assert.IsType(&titleScene{}, g.scenes.Top())
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"asteroid/simulation"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"log"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// saveSlots is the number of games that can be saved at once.
+const saveSlots = 3
+
+// noticeTicks is how long a notice such as a quick save is shown.
+const noticeTicks = 90
+
+// UseSaves lets games be saved to and loaded from slots in dir. While playing,
+// quick save saves to the last slot used and quick load loads it.
+func (g *Game) UseSaves(dir string) {
+	g.saves = dir
+}
+
+// slotPath returns the file of save slot n, counting from 1.
+func (g *Game) slotPath(n int) string {
+	return filepath.Join(g.saves, fmt.Sprintf("slot%d.snapshot", n))
+}
+
+// describeSlot summarizes the game saved in slot n.
+func (g *Game) describeSlot(n int) string {
+	s, err := simulation.LoadSnapshot(g.slotPath(n))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return "EMPTY"
+	case err != nil:
+		return "UNREADABLE"
+	}
+	return fmt.Sprintf("WAVE %d  %06d", s.Wave, s.Score)
+}
+
+// saveSlot saves the game to slot n.
+func (p *playScene) saveSlot(n int) {
+	p.game.slot = n
+	if err := simulation.SaveSnapshot(p.game.slotPath(n), p.world.Snapshot()); err != nil {
+		log.Printf("save game error: %v", err)
+		p.notify("SAVE FAILED")
+		return
+	}
+	p.notify(fmt.Sprintf("SAVED TO SLOT %d", n))
+}
+
+// loadSlot replaces the game with the one saved in slot n. The replay of the
+// game left behind is saved, the loaded game is not recorded as it does not
+// start from the beginning.
+func (p *playScene) loadSlot(n int) {
+	p.game.slot = n
+	s, err := simulation.LoadSnapshot(p.game.slotPath(n))
+	if err != nil {
+		log.Printf("load game error: %v", err)
+		p.notify(fmt.Sprintf("NOTHING TO LOAD IN SLOT %d", n))
+		return
+	}
+	w, err := simulation.Restore(s, p.game.rules.Waves)
+	if err != nil {
+		log.Printf("load game error: %v", err)
+		p.notify("LOAD FAILED")
+		return
+	}
+
+	w.Pool = p.game.pool
+	p.saveReplay()
+	p.rec = nil
+	p.ship = p.recorder.Controller
+	p.world = w
+	log.Printf("Loaded game with seed %d at tick %d", s.Seed, s.Tick)
+	p.notify(fmt.Sprintf("LOADED SLOT %d", n))
+}
+
+// notify shows msg over the game for a little while.
+func (p *playScene) notify(msg string) {
+	p.notice = msg
+	p.noticeLeft = noticeTicks
+}
+
+// slotsScene lists the save slots, to either save the game of play to one or
+// load one into it.
+type slotsScene struct {
+	noHooks
+
+	game *Game
+	menu *menu
+	// slots summarize the game saved in every slot.
+	slots [saveSlots]string
+}
+
+func newSlotsScene(g *Game, play *playScene, save bool) *slotsScene {
+	title := "LOAD GAME"
+	if save {
+		title = "SAVE GAME"
+	}
+
+	s := &slotsScene{game: g}
+	s.refresh()
+
+	var items []menuItem
+	for n := 1; n <= saveSlots; n++ {
+		items = append(items, menuItem{
+			Label: fmt.Sprintf("Slot %d", n),
+			Value: func() string { return s.slots[n-1] },
+			Action: func() {
+				if save {
+					play.saveSlot(n)
+					s.refresh()
+					g.scenes.Pop()
+					return
+				}
+				play.loadSlot(n)
+				// straight back into the loaded game
+				g.scenes.Pop()
+				g.scenes.Pop()
+			},
+		})
+	}
+	items = append(items, menuItem{Label: "Back", Action: g.scenes.Pop})
+
+	s.menu = &menu{Title: title, Items: items, Back: g.scenes.Pop}
+	return s
+}
+
+// refresh reads the summaries of the slots from disk, which is too slow to do
+// on every frame.
+func (s *slotsScene) refresh() {
+	for i := range s.slots {
+		s.slots[i] = s.game.describeSlot(i + 1)
+	}
+}
+
+func (s *slotsScene) Update() error {
+	s.menu.Update(s.game.pressed)
+	return nil
+}
+
+func (s *slotsScene) Draw(screen *ebiten.Image) {
+	s.menu.Draw(screen, s.game.largeFont, s.game.smallFont)
+}
+
+// drawNotice draws the notice of the game at the bottom of the screen.
+func (p *playScene) drawNotice(screen *ebiten.Image) {
+	if p.noticeLeft <= 0 {
+		return
+	}
+	bounds := screen.Bounds()
+	drawCentered(screen, p.notice, p.game.hudFont, float64(bounds.Dx())/2, float64(bounds.Dy())-hudMargin-2*hudFontSize, color.White)
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"asteroid/input"
+	"asteroid/sprite"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaves_SaveAndLoadSlot(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	g.UseSaves(t.TempDir())
+	p := newTestPlay(g)
+	for range 240 {
+		p.world.Step(sprite.Command{RotateLeft: true, Fire: true})
+	}
+	saved := p.world.Snapshot()
+	assert.Equal("EMPTY", g.describeSlot(2))
+
+	p.saveSlot(2)
+	assert.Equal("SAVED TO SLOT 2", p.notice)
+	assert.Equal(2, g.slot, "quick saves go to the last slot used")
+	assert.Equal(fmt.Sprintf("WAVE 1  %06d", p.world.Score), g.describeSlot(2))
+
+	for range 120 {
+		p.world.Step(sprite.Command{Thrust: true})
+	}
+	p.loadSlot(2)
+	assert.Equal(saved, p.world.Snapshot())
+	assert.Nil(p.rec, "a loaded game is not recorded")
+
+	p.loadSlot(3)
+	assert.Equal("NOTHING TO LOAD IN SLOT 3", p.notice)
+	assert.Equal(saved, p.world.Snapshot())
+}
+
+func TestSaves_PauseMenu(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	_, pause := newTestPause(g)
+	assert.Len(pause.menu.Items, 4, "games can not be saved without a directory")
+
+	g.UseSaves(t.TempDir())
+	p, pause := newTestPause(g)
+	p.world.Score = 1200
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Confirm) // Save Game
+	slots := g.scenes.Top().(*slotsScene)
+	assert.Equal("EMPTY", slots.menu.Items[0].Value())
+	slots.menu.press(input.Confirm) // Slot 1
+	assert.Same(pause, g.scenes.Top(), "saving goes back to the pause menu")
+	assert.Equal("WAVE 0  001200", g.describeSlot(1))
+	assert.Equal("WAVE 0  001200", slots.menu.Items[0].Value(), "the slot is read again once saved to")
+
+	p.world.Score = 0
+	pause.menu.press(input.Reverse)
+	pause.menu.press(input.Confirm) // Load Game
+	slots = g.scenes.Top().(*slotsScene)
+	p.saveSlot(2)
+	assert.Equal("EMPTY", slots.menu.Items[1].Value(), "the slots are read once when the menu opens")
+	slots.menu.press(input.Confirm) // Slot 1
+	assert.Same(p, g.scenes.Top(), "loading goes straight back to the game")
+	assert.Equal(1200, p.world.Score)
+}
+
+func TestSaves_QuickSaveAndLoad(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGame()
+	g.UseSaves(t.TempDir())
+	p := newTestPlay(g)
+	p.world.Score = 500
+
+	g.pressed = input.NewActionSet(input.QuickSave)
+	assert.NoError(p.Update())
+	assert.Equal("SAVED TO SLOT 1", p.notice)
+
+	p.world.Score = 0
+	g.pressed = input.NewActionSet(input.QuickLoad)
+	assert.NoError(p.Update())
+	assert.Equal("LOADED SLOT 1", p.notice)
+	assert.Equal(500, p.world.Score)
+}
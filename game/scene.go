@@ -0,0 +1,169 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Scene is a single screen of the game, such as the title screen or the
+// playing field. Scenes are kept on a stack by a SceneManager.
+type Scene interface {
+	// Update is called every tick while the scene is on top of the stack.
+	Update() error
+	// Draw is called every frame for every scene on the stack, bottom first,
+	// so overlays are drawn over the scenes below them.
+	Draw(screen *ebiten.Image)
+	// Enter is called when the scene becomes the top of the stack.
+	Enter()
+	// Exit is called when the scene stops being the top of the stack.
+	Exit()
+}
+
+// noHooks provides empty Enter and Exit methods for scenes which need neither.
+type noHooks struct{}
+
+func (noHooks) Enter() {}
+
+func (noHooks) Exit() {}
+
+// fadeTicks is how long each half of a transition between scenes lasts.
+const fadeTicks = 20
+
+type fadePhase int
+
+const (
+	fadeNone fadePhase = iota
+	fadeOut
+	fadeIn
+)
+
+// SceneManager owns the scene stack. Overlays such as the pause menu are
+// pushed onto and popped off the stack immediately, while Switch fades the
+// screen to black, replaces the whole stack and fades back in.
+type SceneManager struct {
+	stack []Scene
+
+	phase fadePhase
+	tick  int
+	// pending replaces the stack once the screen has faded out.
+	pending Scene
+}
+
+func NewSceneManager() *SceneManager {
+	return &SceneManager{}
+}
+
+// Top returns the scene being updated, or nil if the stack is empty.
+func (m *SceneManager) Top() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// Push puts s on top of the stack.
+func (m *SceneManager) Push(s Scene) {
+	if top := m.Top(); top != nil {
+		top.Exit()
+	}
+	m.stack = append(m.stack, s)
+	s.Enter()
+}
+
+// Pop removes the top scene and returns to the one below it.
+func (m *SceneManager) Pop() {
+	top := m.Top()
+	if top == nil {
+		return
+	}
+	top.Exit()
+	m.stack[len(m.stack)-1] = nil
+	m.stack = m.stack[:len(m.stack)-1]
+	if top := m.Top(); top != nil {
+		top.Enter()
+	}
+}
+
+// Switch fades out, replaces the whole stack with s and fades back in. No
+// scene is updated during the transition.
+func (m *SceneManager) Switch(s Scene) {
+	m.pending = s
+	if m.phase == fadeOut {
+		return
+	}
+
+	// carry on from how dark the screen already is
+	switch {
+	case len(m.stack) == 0:
+		m.tick = fadeTicks
+	case m.phase == fadeIn:
+		m.tick = fadeTicks - m.tick
+	default:
+		m.tick = 0
+	}
+	m.phase = fadeOut
+}
+
+// Transitioning reports whether the screen is fading between scenes.
+func (m *SceneManager) Transitioning() bool {
+	return m.phase != fadeNone
+}
+
+func (m *SceneManager) Update() error {
+	switch m.phase {
+	case fadeOut:
+		m.tick++
+		if m.tick >= fadeTicks {
+			m.replace(m.pending)
+			m.pending = nil
+			m.phase = fadeIn
+			m.tick = 0
+		}
+		return nil
+	case fadeIn:
+		m.tick++
+		if m.tick >= fadeTicks {
+			m.phase = fadeNone
+		}
+		return nil
+	}
+
+	if top := m.Top(); top != nil {
+		return top.Update()
+	}
+	return nil
+}
+
+func (m *SceneManager) replace(s Scene) {
+	if top := m.Top(); top != nil {
+		top.Exit()
+	}
+	clear(m.stack)
+	m.stack = append(m.stack[:0], s)
+	s.Enter()
+}
+
+func (m *SceneManager) Draw(screen *ebiten.Image) {
+	for _, s := range m.stack {
+		s.Draw(screen)
+	}
+
+	if a := m.darkness(); a > 0 {
+		bounds := screen.Bounds()
+		c := color.RGBA{A: uint8(a * 255)}
+		vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), c, false)
+	}
+}
+
+// darkness returns how far the screen has faded to black, from 0 to 1.
+func (m *SceneManager) darkness() float64 {
+	switch m.phase {
+	case fadeOut:
+		return float64(m.tick) / fadeTicks
+	case fadeIn:
+		return 1 - float64(m.tick)/fadeTicks
+	}
+	return 0
+}
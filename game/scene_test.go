@@ -0,0 +1,86 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeScene records the calls made to it in a shared log.
+type fakeScene struct {
+	name string
+	log  *[]string
+}
+
+func (s *fakeScene) Update() error {
+	*s.log = append(*s.log, s.name+".Update")
+	return nil
+}
+
+func (s *fakeScene) Draw(screen *ebiten.Image) {}
+
+func (s *fakeScene) Enter() { *s.log = append(*s.log, s.name+".Enter") }
+
+func (s *fakeScene) Exit() { *s.log = append(*s.log, s.name+".Exit") }
+
+func TestSceneManager_PushPop(t *testing.T) {
+	assert := assert.New(t)
+	var log []string
+	a := &fakeScene{name: "a", log: &log}
+	b := &fakeScene{name: "b", log: &log}
+	m := NewSceneManager()
+
+	m.Push(a)
+	m.Push(b)
+	assert.Same(b, m.Top())
+	assert.NoError(m.Update())
+	m.Pop()
+	assert.Same(a, m.Top())
+	assert.NoError(m.Update())
+
+	assert.Equal([]string{"a.Enter", "a.Exit", "b.Enter", "b.Update", "b.Exit", "a.Enter", "a.Update"}, log)
+
+	m.Pop()
+	assert.Nil(m.Top())
+	m.Pop() // popping an empty stack is harmless
+}
+
+func TestSceneManager_SwitchFades(t *testing.T) {
+	assert := assert.New(t)
+	var log []string
+	a := &fakeScene{name: "a", log: &log}
+	b := &fakeScene{name: "b", log: &log}
+	c := &fakeScene{name: "c", log: &log}
+	m := NewSceneManager()
+
+	// the first scene only fades in
+	m.Switch(a)
+	m.Update()
+	assert.Same(a, m.Top())
+	assert.Equal(1.0, m.darkness())
+	for m.Transitioning() {
+		m.Update()
+	}
+	assert.Equal([]string{"a.Enter"}, log)
+
+	log = log[:0]
+	m.Push(b)
+	m.Switch(c)
+	for range fadeTicks - 1 {
+		m.Update()
+	}
+	assert.Same(b, m.Top(), "the stack is replaced once the screen is black")
+	assert.InDelta(1, m.darkness(), 0.1)
+	m.Update()
+	assert.Same(c, m.Top())
+	assert.Len(m.stack, 1)
+
+	for range fadeTicks {
+		m.Update()
+	}
+	assert.False(m.Transitioning())
+	assert.Equal(0.0, m.darkness())
+	m.Update()
+	assert.Equal([]string{"a.Exit", "b.Enter", "b.Exit", "c.Enter", "c.Update"}, log, "no scene is updated while fading")
+}
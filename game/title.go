@@ -0,0 +1,37 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// titleScene is the main menu the game opens on.
+type titleScene struct {
+	noHooks
+
+	game *Game
+	menu *menu
+}
+
+func newTitleScene(g *Game) *titleScene {
+	return &titleScene{
+		game: g,
+		menu: &menu{
+			Title: "GEOMETRY MATRIX",
+			Items: []menuItem{
+				{Label: "Start", Action: g.startGame},
+				{Label: "High Scores", Action: func() { g.scenes.Push(newHighScoresScene(g, -1)) }},
+				{Label: "Settings", Action: func() { g.scenes.Push(newSettingsScene(g)) }},
+				{Label: "Quit", Action: func() { g.quit = true }},
+			},
+		},
+	}
+}
+
+func (t *titleScene) Update() error {
+	t.menu.Update(t.game.pressed)
+	return nil
+}
+
+func (t *titleScene) Draw(screen *ebiten.Image) {
+	t.menu.Draw(screen, t.game.largeFont, t.game.smallFont)
+}
@@ -0,0 +1,68 @@
+package highscore
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Entry is a single finished game on the table.
+type Entry struct {
+	Name  string    `json:"name"`
+	Score int       `json:"score"`
+	Date  time.Time `json:"date"`
+	Seed  uint64    `json:"seed"`
+	Mode  string    `json:"mode"`
+}
+
+// DefaultSize is the number of entries on the table.
+const DefaultSize = 10
+
+// Table keeps the best Size entries, highest score first. Ties keep the
+// earlier entry ahead.
+type Table struct {
+	Size    int
+	Entries []Entry
+}
+
+func NewTable(size int) *Table {
+	return &Table{Size: size}
+}
+
+// Qualifies reports whether a game with score would make it onto the table.
+func (t *Table) Qualifies(score int) bool {
+	if score <= 0 || t.Size <= 0 {
+		return false
+	}
+	return len(t.Entries) < t.Size || score > t.Entries[len(t.Entries)-1].Score
+}
+
+// Add puts e on the table and returns its rank, starting at 0, or -1 if it
+// did not qualify.
+func (t *Table) Add(e Entry) int {
+	if !t.Qualifies(e.Score) {
+		return -1
+	}
+	i, _ := slices.BinarySearchFunc(t.Entries, e.Score, func(o Entry, score int) int {
+		// descending, and after any entry with the same score
+		if o.Score >= score {
+			return -1
+		}
+		return 1
+	})
+	t.Entries = slices.Insert(t.Entries, i, e)
+	if len(t.Entries) > t.Size {
+		t.Entries = t.Entries[:t.Size]
+	}
+	return i
+}
+
+// sort orders entries loaded from a file, which may have been edited by hand.
+func (t *Table) sort() {
+	slices.SortStableFunc(t.Entries, func(a, b Entry) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if len(t.Entries) > t.Size {
+		t.Entries = t.Entries[:t.Size]
+	}
+}
@@ -0,0 +1,38 @@
+package highscore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/highscore"
+)
+
+func scores(t *highscore.Table) []int {
+	s := []int{}
+	for _, e := range t.Entries {
+		s = append(s, e.Score)
+	}
+	return s
+}
+
+func TestTableAdd(t *testing.T) {
+	assert := assert.New(t)
+	table := highscore.NewTable(3)
+
+	assert.False(table.Qualifies(0), "an empty game does not qualify")
+	assert.Equal(0, table.Add(highscore.Entry{Name: "A", Score: 100}))
+	assert.Equal(0, table.Add(highscore.Entry{Name: "B", Score: 300}))
+	assert.Equal(1, table.Add(highscore.Entry{Name: "C", Score: 200}))
+	assert.Equal([]int{300, 200, 100}, scores(table))
+
+	// ties rank after the earlier entry
+	assert.True(table.Qualifies(150))
+	assert.Equal(2, table.Add(highscore.Entry{Name: "D", Score: 200}))
+	assert.Equal([]int{300, 200, 200}, scores(table))
+	assert.Equal("C", table.Entries[1].Name)
+
+	assert.False(table.Qualifies(200), "a full table needs a better score than the last entry")
+	assert.Equal(-1, table.Add(highscore.Entry{Name: "E", Score: 50}))
+	assert.Equal([]int{300, 200, 200}, scores(table))
+}
@@ -0,0 +1,84 @@
+package highscore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+
+	"asteroid/storage"
+)
+
+const (
+	fileName = "highscores.json"
+
+	// fileVersion is bumped whenever the layout of the file changes.
+	fileVersion = 1
+)
+
+// file is the layout of the high-score file on disk.
+type file struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Store persists a Table as JSON at Path.
+type Store struct {
+	Path string
+	Size int
+}
+
+// DefaultPath returns the high-score file under the user's config directory.
+func DefaultPath() (string, error) {
+	return storage.Path(fileName)
+}
+
+func NewStore(path string, size int) *Store {
+	return &Store{Path: path, Size: size}
+}
+
+// Load reads the table from disk. A missing file is an empty table. A file
+// which can not be parsed is moved aside to Path.corrupt, so it is not
+// overwritten by the next Save, and an empty table is returned.
+func (s *Store) Load() (*Table, error) {
+	t := NewTable(s.Size)
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil || f.Version != fileVersion {
+		if err == nil {
+			err = fmt.Errorf("unsupported version %d", f.Version)
+		}
+		log.Printf("High-score file %s is corrupt, starting a new one: %v", s.Path, err)
+		if err := os.Rename(s.Path, s.Path+".corrupt"); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+
+	t.Entries = f.Entries
+	t.sort()
+	return t, nil
+}
+
+// Save replaces the file at Path with the table.
+func (s *Store) Save(t *Table) error {
+	data, err := json.MarshalIndent(file{Version: fileVersion, Entries: t.Entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return storage.WriteFile(s.Path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
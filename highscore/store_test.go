@@ -0,0 +1,81 @@
+package highscore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/highscore"
+)
+
+func TestStoreLoadMissing(t *testing.T) {
+	assert := assert.New(t)
+	s := highscore.NewStore(filepath.Join(t.TempDir(), "scores.json"), 5)
+
+	table, err := s.Load()
+	assert.NoError(err)
+	assert.Empty(table.Entries)
+	assert.Equal(5, table.Size)
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "nested", "scores.json")
+	s := highscore.NewStore(path, 5)
+
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	table := highscore.NewTable(5)
+	table.Add(highscore.Entry{Name: "ACE", Score: 1200, Date: date, Seed: 42, Mode: "classic"})
+	table.Add(highscore.Entry{Name: "BOB", Score: 800, Date: date, Seed: 7, Mode: "arcade"})
+	assert.NoError(s.Save(table))
+
+	loaded, err := s.Load()
+	assert.NoError(err)
+	assert.Equal(table.Entries, loaded.Entries)
+
+	// no temporary file is left behind
+	files, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(files, 1)
+}
+
+func TestStoreLoadSortsAndTrims(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "scores.json")
+	data := `{"version": 1, "entries": [{"name": "A", "score": 10}, {"name": "B", "score": 30}, {"name": "C", "score": 20}]}`
+	assert.NoError(os.WriteFile(path, []byte(data), 0o644))
+
+	table, err := highscore.NewStore(path, 2).Load()
+	assert.NoError(err)
+	assert.Equal([]int{30, 20}, scores(table))
+}
+
+func TestStoreLoadCorrupt(t *testing.T) {
+	assert := assert.New(t)
+	for name, data := range map[string]string{
+		"truncated": `{"version": 1, "entries": [{"name": "A"`,
+		"version":   `{"version": 99, "entries": []}`,
+	} {
+		path := filepath.Join(t.TempDir(), "scores.json")
+		assert.NoError(os.WriteFile(path, []byte(data), 0o644))
+
+		s := highscore.NewStore(path, 5)
+		table, err := s.Load()
+		assert.NoError(err, name)
+		assert.Empty(table.Entries, name)
+
+		// the corrupt file is kept aside and the next save starts afresh
+		kept, err := os.ReadFile(path + ".corrupt")
+		assert.NoError(err, name)
+		assert.Equal(data, string(kept), name)
+		assert.NoFileExists(path, name)
+
+		table.Add(highscore.Entry{Name: "A", Score: 10})
+		assert.NoError(s.Save(table), name)
+		loaded, err := s.Load()
+		assert.NoError(err, name)
+		assert.Equal([]int{10}, scores(loaded), name)
+	}
+}
@@ -0,0 +1,94 @@
+package input
+
+import "fmt"
+
+// Action is something the player does, whatever input it is bound to.
+type Action int
+
+const (
+	Thrust Action = iota
+	Reverse
+	RotateLeft
+	RotateRight
+	Fire
+	Pause
+	Confirm
+	HighScores
+	QuickSave
+	QuickLoad
+
+	actionCount
+)
+
+var actionNames = [actionCount]string{
+	Thrust:      "thrust",
+	Reverse:     "reverse",
+	RotateLeft:  "rotate_left",
+	RotateRight: "rotate_right",
+	Fire:        "fire",
+	Pause:       "pause",
+	Confirm:     "confirm",
+	HighScores:  "high_scores",
+	QuickSave:   "quick_save",
+	QuickLoad:   "quick_load",
+}
+
+// Actions lists every action, in the order they are shown in menus.
+func Actions() []Action {
+	actions := make([]Action, actionCount)
+	for i := range actions {
+		actions[i] = Action(i)
+	}
+	return actions
+}
+
+func (a Action) String() string {
+	if a < 0 || a >= actionCount {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+func (a Action) MarshalText() ([]byte, error) {
+	if a < 0 || a >= actionCount {
+		return nil, fmt.Errorf("unknown action %d", int(a))
+	}
+	return []byte(actionNames[a]), nil
+}
+
+func (a *Action) UnmarshalText(text []byte) error {
+	for i, name := range actionNames {
+		if name == string(text) {
+			*a = Action(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action %q", text)
+}
+
+// ActionSet is a set of actions, such as the ones held during a tick.
+type ActionSet uint32
+
+// NewActionSet returns the set of actions.
+func NewActionSet(actions ...Action) ActionSet {
+	var s ActionSet
+	for _, a := range actions {
+		s = s.With(a)
+	}
+	return s
+}
+
+// Has reports whether a is in the set.
+func (s ActionSet) Has(a Action) bool {
+	return s&(1<<a) != 0
+}
+
+// With returns the set with a added.
+func (s ActionSet) With(a Action) ActionSet {
+	return s | 1<<a
+}
+
+// Without returns the set with a removed.
+func (s ActionSet) Without(a Action) ActionSet {
+	return s &^ (1 << a)
+}
@@ -0,0 +1,42 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/input"
+)
+
+func TestActionSet(t *testing.T) {
+	assert := assert.New(t)
+	s := input.NewActionSet(input.Thrust, input.Fire)
+	assert.True(s.Has(input.Thrust))
+	assert.True(s.Has(input.Fire))
+	assert.False(s.Has(input.Reverse))
+
+	s = s.With(input.Reverse).With(input.Fire)
+	assert.Equal(input.NewActionSet(input.Thrust, input.Reverse, input.Fire), s)
+
+	var none input.ActionSet
+	for _, a := range input.Actions() {
+		assert.False(none.Has(a))
+	}
+}
+
+func TestActionText(t *testing.T) {
+	assert := assert.New(t)
+	assert.Len(input.Actions(), 10)
+	for _, a := range input.Actions() {
+		text, err := a.MarshalText()
+		assert.NoError(err)
+
+		var back input.Action
+		assert.NoError(back.UnmarshalText(text))
+		assert.Equal(a, back)
+	}
+	assert.Equal("rotate_left", input.RotateLeft.String())
+
+	var a input.Action
+	assert.Error(a.UnmarshalText([]byte("jump")))
+}
@@ -0,0 +1,92 @@
+package input
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Bindings maps keys to actions. An action can be bound to several keys, a
+// key to a single action.
+type Bindings struct {
+	keys [actionCount][]ebiten.Key
+}
+
+// required are the actions which always keep a key. Without them no menu can
+// be picked from or left.
+var required = []Action{Pause, Confirm}
+
+// DefaultBindings returns the bindings the game ships with.
+func DefaultBindings() *Bindings {
+	b := &Bindings{}
+	b.keys[Thrust] = []ebiten.Key{ebiten.KeyW, ebiten.KeyArrowUp}
+	b.keys[Reverse] = []ebiten.Key{ebiten.KeyS, ebiten.KeyArrowDown}
+	b.keys[RotateLeft] = []ebiten.Key{ebiten.KeyA, ebiten.KeyArrowLeft}
+	b.keys[RotateRight] = []ebiten.Key{ebiten.KeyD, ebiten.KeyArrowRight}
+	b.keys[Fire] = []ebiten.Key{ebiten.KeySpace}
+	b.keys[Pause] = []ebiten.Key{ebiten.KeyEscape, ebiten.KeyP}
+	b.keys[Confirm] = []ebiten.Key{ebiten.KeyEnter}
+	b.keys[HighScores] = []ebiten.Key{ebiten.KeyH}
+	b.keys[QuickSave] = []ebiten.Key{ebiten.KeyF5}
+	b.keys[QuickLoad] = []ebiten.Key{ebiten.KeyF9}
+	return b
+}
+
+// Keys returns the keys bound to a.
+func (b *Bindings) Keys(a Action) []ebiten.Key {
+	return b.keys[a]
+}
+
+// Bind makes k the only key of a, taking it away from any other action. It
+// fails, changing nothing, if k is the last key of a required action.
+func (b *Bindings) Bind(a Action, k ebiten.Key) error {
+	for _, r := range required {
+		if r != a && slices.Equal(b.keys[r], []ebiten.Key{k}) {
+			return fmt.Errorf("%s is the last key of %s", k, r)
+		}
+	}
+	for i := range b.keys {
+		b.keys[i] = slices.DeleteFunc(b.keys[i], func(bound ebiten.Key) bool { return bound == k })
+	}
+	b.keys[a] = []ebiten.Key{k}
+	return nil
+}
+
+// Actions returns the actions bound to any of keys.
+func (b *Bindings) Actions(keys []ebiten.Key) ActionSet {
+	var s ActionSet
+	for a, bound := range b.keys {
+		for _, k := range keys {
+			if slices.Contains(bound, k) {
+				s = s.With(Action(a))
+				break
+			}
+		}
+	}
+	return s
+}
+
+// Describe returns the keys bound to a as they are labelled on the keyboard
+// layout in use, such as "Z / UP" for the W key on an AZERTY keyboard.
+func (b *Bindings) Describe(a Action) string {
+	if len(b.keys[a]) == 0 {
+		return "NONE"
+	}
+	names := make([]string, len(b.keys[a]))
+	for i, k := range b.keys[a] {
+		names[i] = KeyLabel(k)
+	}
+	return strings.Join(names, " / ")
+}
+
+// KeyLabel returns the label of k on the keyboard layout in use, falling back
+// to its name on a US keyboard.
+func KeyLabel(k ebiten.Key) string {
+	name := ebiten.KeyName(k)
+	if name == "" {
+		name = strings.TrimPrefix(k.String(), "Arrow")
+	}
+	return strings.ToUpper(name)
+}
@@ -0,0 +1,52 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/input"
+)
+
+func TestBindingsActions(t *testing.T) {
+	assert := assert.New(t)
+	b := input.DefaultBindings()
+
+	assert.Equal(input.ActionSet(0), b.Actions(nil))
+	assert.Equal(input.NewActionSet(input.Thrust, input.Fire), b.Actions([]ebiten.Key{ebiten.KeyW, ebiten.KeySpace}))
+	assert.Equal(input.NewActionSet(input.Thrust), b.Actions([]ebiten.Key{ebiten.KeyW, ebiten.KeyArrowUp}))
+	assert.Equal(input.NewActionSet(input.Pause), b.Actions([]ebiten.Key{ebiten.KeyP, ebiten.KeyQ}))
+}
+
+func TestBindingsBind(t *testing.T) {
+	assert := assert.New(t)
+	b := input.DefaultBindings()
+
+	// ZQSD on an AZERTY keyboard, bound by the keys a US keyboard has there
+	b.Bind(input.RotateLeft, ebiten.KeyQ)
+	assert.Equal([]ebiten.Key{ebiten.KeyQ}, b.Keys(input.RotateLeft))
+	assert.Equal(input.NewActionSet(input.RotateLeft), b.Actions([]ebiten.Key{ebiten.KeyQ}))
+	assert.Equal(input.ActionSet(0), b.Actions([]ebiten.Key{ebiten.KeyA}))
+
+	// a key taken from another action is no longer bound to it
+	b.Bind(input.Fire, ebiten.KeyW)
+	assert.Equal([]ebiten.Key{ebiten.KeyArrowUp}, b.Keys(input.Thrust))
+	assert.Equal(input.NewActionSet(input.Fire), b.Actions([]ebiten.Key{ebiten.KeyW}))
+	assert.Equal(input.ActionSet(0), b.Actions([]ebiten.Key{ebiten.KeySpace}))
+
+	assert.Equal([]ebiten.Key{ebiten.KeyS, ebiten.KeyArrowDown}, input.DefaultBindings().Keys(input.Reverse), "the defaults are not changed")
+}
+
+func TestBindingsBindRequired(t *testing.T) {
+	assert := assert.New(t)
+	b := input.DefaultBindings()
+
+	assert.Error(b.Bind(input.Fire, ebiten.KeyEnter), "the only key of Confirm")
+	assert.Equal(input.DefaultBindings(), b)
+
+	assert.NoError(b.Bind(input.Fire, ebiten.KeyP))
+	assert.Error(b.Bind(input.Thrust, ebiten.KeyEscape), "the last key of Pause")
+	assert.Equal([]ebiten.Key{ebiten.KeyEscape}, b.Keys(input.Pause))
+	assert.NoError(b.Bind(input.Pause, ebiten.KeyEscape), "rebinding the action itself")
+}
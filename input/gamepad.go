@@ -0,0 +1,152 @@
+package input
+
+import (
+	"log"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// DefaultDeadzone is how far a stick has to be pushed, from 0 to 1, before it
+// counts, so worn sticks resting slightly off center do not steer the ship.
+const DefaultDeadzone = 0.25
+
+// GamepadBindings maps the buttons of gamepads with the standard layout, as
+// named by ebiten, to actions. Unlike keys, a button can trigger several
+// actions, so A both fires and confirms.
+type GamepadBindings struct {
+	buttons [actionCount][]ebiten.StandardGamepadButton
+}
+
+// DefaultGamepadBindings returns the gamepad layout of the game: the left
+// stick or the d-pad steers, the triggers thrust and reverse, A fires and
+// confirms, Start and B pause and go back, Y shows the high scores and the
+// right and left bumpers quick save and quick load.
+func DefaultGamepadBindings() *GamepadBindings {
+	b := &GamepadBindings{}
+	b.buttons[Thrust] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop, ebiten.StandardGamepadButtonFrontBottomRight}
+	b.buttons[Reverse] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftBottom, ebiten.StandardGamepadButtonFrontBottomLeft}
+	b.buttons[RotateLeft] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft}
+	b.buttons[RotateRight] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight}
+	b.buttons[Fire] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}
+	b.buttons[Pause] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight, ebiten.StandardGamepadButtonRightRight}
+	b.buttons[Confirm] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}
+	b.buttons[HighScores] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightTop}
+	b.buttons[QuickSave] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontTopRight}
+	b.buttons[QuickLoad] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonFrontTopLeft}
+	return b
+}
+
+// Buttons returns the buttons bound to a.
+func (b *GamepadBindings) Buttons(a Action) []ebiten.StandardGamepadButton {
+	return b.buttons[a]
+}
+
+// Actions returns the actions bound to any of buttons.
+func (b *GamepadBindings) Actions(buttons []ebiten.StandardGamepadButton) ActionSet {
+	var s ActionSet
+	for a, bound := range b.buttons {
+		for _, btn := range buttons {
+			if slices.Contains(bound, btn) {
+				s = s.With(Action(a))
+				break
+			}
+		}
+	}
+	return s
+}
+
+// StickActions returns the actions of a stick pushed to x, y, each from -1 to
+// 1 with y growing downwards. Pushes within deadzone of the center are
+// ignored, past it up thrusts, down reverses and the sides rotate. An axis
+// only counts once it is pushed past the deadzone itself, so a push straight
+// up does not also rotate.
+func StickActions(x, y, deadzone float64) ActionSet {
+	var s ActionSet
+	if math.Hypot(x, y) <= deadzone {
+		return s
+	}
+	switch {
+	case y < -deadzone:
+		s = s.With(Thrust)
+	case y > deadzone:
+		s = s.With(Reverse)
+	}
+	switch {
+	case x < -deadzone:
+		s = s.With(RotateLeft)
+	case x > deadzone:
+		s = s.With(RotateRight)
+	}
+	return s
+}
+
+// Gamepads reads every connected gamepad with the standard layout, noticing
+// gamepads plugged in and out while the game runs. All of them steer the
+// same ship.
+type Gamepads struct {
+	Bindings *GamepadBindings
+	Deadzone float64
+
+	ids       []ebiten.GamepadID
+	connected []ebiten.GamepadID
+	buttons   []ebiten.StandardGamepadButton
+	// stick are the actions of the sticks on the last update, to tell when a
+	// stick is pushed.
+	stick ActionSet
+}
+
+func NewGamepads() *Gamepads {
+	return &Gamepads{
+		Bindings: DefaultGamepadBindings(),
+		Deadzone: DefaultDeadzone,
+	}
+}
+
+// Update reads the gamepads for this tick. held are the actions held on any of
+// them, pressed the ones whose button was pressed or stick pushed since the
+// last tick.
+func (g *Gamepads) Update() (held, pressed ActionSet) {
+	g.plug()
+
+	var stick ActionSet
+	for _, id := range g.ids {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		g.buttons = inpututil.AppendPressedStandardGamepadButtons(id, g.buttons[:0])
+		held |= g.Bindings.Actions(g.buttons)
+		g.buttons = inpututil.AppendJustPressedStandardGamepadButtons(id, g.buttons[:0])
+		pressed |= g.Bindings.Actions(g.buttons)
+
+		x := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		y := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		stick |= StickActions(x, y, g.Deadzone)
+	}
+
+	held |= stick
+	pressed |= stick &^ g.stick
+	g.stick = stick
+	return held, pressed
+}
+
+// plug logs the gamepads plugged in and out since the last update and lists
+// the connected ones.
+func (g *Gamepads) plug() {
+	for _, id := range g.ids {
+		if inpututil.IsGamepadJustDisconnected(id) {
+			log.Printf("Gamepad %d disconnected", id)
+		}
+	}
+	g.connected = inpututil.AppendJustConnectedGamepadIDs(g.connected[:0])
+	for _, id := range g.connected {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			log.Printf("Gamepad %d connected: %s", id, ebiten.GamepadName(id))
+		} else {
+			log.Printf("Gamepad %d connected without a standard layout, it is ignored: %s", id, ebiten.GamepadName(id))
+		}
+	}
+	g.ids = ebiten.AppendGamepadIDs(g.ids[:0])
+}
@@ -0,0 +1,54 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/input"
+)
+
+func TestStickActions(t *testing.T) {
+	assert := assert.New(t)
+	dz := input.DefaultDeadzone
+
+	cases := []struct {
+		name string
+		x, y float64
+		want input.ActionSet
+	}{
+		{"centered", 0, 0, 0},
+		{"resting off center", 0.15, -0.15, 0},
+		{"up", 0, -1, input.NewActionSet(input.Thrust)},
+		{"down", 0, 0.6, input.NewActionSet(input.Reverse)},
+		{"left", -0.8, 0.1, input.NewActionSet(input.RotateLeft)},
+		{"right", 0.3, 0, input.NewActionSet(input.RotateRight)},
+		{"up and right", 0.7, -0.7, input.NewActionSet(input.Thrust, input.RotateRight)},
+		{"past the deadzone on neither axis", 0.2, -0.2, 0},
+	}
+	for _, c := range cases {
+		assert.Equal(c.want, input.StickActions(c.x, c.y, dz), c.name)
+	}
+}
+
+func TestGamepadBindingsActions(t *testing.T) {
+	assert := assert.New(t)
+	b := input.DefaultGamepadBindings()
+
+	assert.Equal(input.ActionSet(0), b.Actions(nil))
+	assert.Equal(input.NewActionSet(input.Fire, input.Confirm), b.Actions([]ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}))
+	assert.Equal(input.NewActionSet(input.Thrust, input.RotateLeft), b.Actions([]ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonFrontBottomRight,
+		ebiten.StandardGamepadButtonLeftLeft,
+	}))
+	assert.Equal(input.NewActionSet(input.Pause), b.Actions([]ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight}))
+}
+
+func TestGamepadsWithoutGamepads(t *testing.T) {
+	assert := assert.New(t)
+	g := input.NewGamepads()
+	held, pressed := g.Update()
+	assert.Zero(held)
+	assert.Zero(pressed)
+}
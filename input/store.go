@@ -0,0 +1,130 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"asteroid/storage"
+)
+
+const (
+	fileName = "controls.json"
+
+	// fileVersion is bumped whenever the layout of the file changes.
+	fileVersion = 1
+)
+
+// file is the layout of the bindings file on disk. Keys are stored by their
+// name on a US keyboard, such as "W" or "ArrowUp".
+type file struct {
+	Version  int                     `json:"version"`
+	Bindings map[Action][]ebiten.Key `json:"bindings"`
+}
+
+// Store persists Bindings as JSON at Path.
+type Store struct {
+	Path string
+}
+
+// DefaultPath returns the bindings file under the user's config directory.
+func DefaultPath() (string, error) {
+	return storage.Path(fileName)
+}
+
+func NewStore(path string) *Store {
+	return &Store{Path: path}
+}
+
+// Load reads the bindings from disk. Actions missing from the file, or left
+// without keys, get their default keys no other action took, and a missing
+// file is the default bindings. A file which can not be parsed, binds a key to
+// two actions or leaves a required action without keys is moved aside to
+// Path.corrupt and the defaults are returned.
+func (s *Store) Load() (*Bindings, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultBindings(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	var b *Bindings
+	err = json.Unmarshal(data, &f)
+	if err == nil && f.Version != fileVersion {
+		err = fmt.Errorf("unsupported version %d", f.Version)
+	}
+	if err == nil {
+		b, err = f.bindings()
+	}
+	if err != nil {
+		log.Printf("Controls file %s is corrupt, using the default controls: %v", s.Path, err)
+		if err := os.Rename(s.Path, s.Path+".corrupt"); err != nil {
+			return nil, err
+		}
+		return DefaultBindings(), nil
+	}
+	return b, nil
+}
+
+// bindings returns the bindings of the file, filling in the default keys of
+// the actions it leaves without any.
+func (f *file) bindings() (*Bindings, error) {
+	b := &Bindings{}
+	owner := map[ebiten.Key]Action{}
+	take := func(a Action, k ebiten.Key) {
+		if _, ok := owner[k]; !ok {
+			owner[k] = a
+			b.keys[a] = append(b.keys[a], k)
+		}
+	}
+
+	for _, a := range Actions() {
+		for _, k := range f.Bindings[a] {
+			if o, ok := owner[k]; ok && o != a {
+				return nil, fmt.Errorf("%s is bound to both %s and %s", k, o, a)
+			}
+			take(a, k)
+		}
+	}
+
+	defaults := DefaultBindings()
+	for _, a := range Actions() {
+		if len(b.keys[a]) > 0 {
+			continue
+		}
+		for _, k := range defaults.keys[a] {
+			take(a, k)
+		}
+	}
+	for _, a := range required {
+		if len(b.keys[a]) == 0 {
+			return nil, fmt.Errorf("no key left for %s", a)
+		}
+	}
+	return b, nil
+}
+
+// Save replaces the file at Path with the bindings.
+func (s *Store) Save(b *Bindings) error {
+	f := file{Version: fileVersion, Bindings: make(map[Action][]ebiten.Key, actionCount)}
+	for a, keys := range b.keys {
+		f.Bindings[Action(a)] = keys
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return storage.WriteFile(s.Path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
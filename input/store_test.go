@@ -0,0 +1,78 @@
+package input_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/input"
+)
+
+func TestStoreLoadMissing(t *testing.T) {
+	assert := assert.New(t)
+	s := input.NewStore(filepath.Join(t.TempDir(), "controls.json"))
+
+	b, err := s.Load()
+	assert.NoError(err)
+	assert.Equal(input.DefaultBindings(), b)
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "nested", "controls.json")
+	s := input.NewStore(path)
+
+	b := input.DefaultBindings()
+	b.Bind(input.Thrust, ebiten.KeyComma)
+	b.Bind(input.Fire, ebiten.KeyControlLeft)
+	assert.NoError(s.Save(b))
+
+	loaded, err := s.Load()
+	assert.NoError(err)
+	assert.Equal(b, loaded)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Contains(string(data), `"thrust": [`+"\n"+`      "Comma"`, "keys are stored by name")
+}
+
+func TestStoreLoadPartial(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "controls.json")
+	data := `{"version": 1, "bindings": {"fire": ["F", "P"], "thrust": [], "rotate_left": ["A", "A"]}}`
+	assert.NoError(os.WriteFile(path, []byte(data), 0o644))
+
+	b, err := input.NewStore(path).Load()
+	assert.NoError(err)
+	assert.Equal([]ebiten.Key{ebiten.KeyF, ebiten.KeyP}, b.Keys(input.Fire))
+	assert.Equal([]ebiten.Key{ebiten.KeyA}, b.Keys(input.RotateLeft))
+	assert.Equal(input.DefaultBindings().Keys(input.Thrust), b.Keys(input.Thrust), "actions left empty get their keys back")
+	assert.Equal(input.DefaultBindings().Keys(input.Reverse), b.Keys(input.Reverse), "actions left out keep their keys")
+	assert.Equal([]ebiten.Key{ebiten.KeyEscape}, b.Keys(input.Pause), "keys taken by the file are not given back")
+}
+
+func TestStoreLoadCorrupt(t *testing.T) {
+	assert := assert.New(t)
+	for name, data := range map[string]string{
+		"truncated":  `{"version": 1, "bindings": {"fire": [`,
+		"version":    `{"version": 99, "bindings": {}}`,
+		"bad key":    `{"version": 1, "bindings": {"fire": ["Trigger"]}}`,
+		"bad action": `{"version": 1, "bindings": {"jump": ["Space"]}}`,
+		"shared key": `{"version": 1, "bindings": {"fire": ["W"], "thrust": ["W"]}}`,
+		"no pause":   `{"version": 1, "bindings": {"fire": ["Escape", "P"], "pause": []}}`,
+	} {
+		path := filepath.Join(t.TempDir(), "controls.json")
+		assert.NoError(os.WriteFile(path, []byte(data), 0o644))
+
+		b, err := input.NewStore(path).Load()
+		assert.NoError(err, name)
+		assert.Equal(input.DefaultBindings(), b, name)
+
+		kept, err := os.ReadFile(path + ".corrupt")
+		assert.NoError(err, name)
+		assert.Equal(data, string(kept), name)
+	}
+}
@@ -0,0 +1,107 @@
+package level
+
+import (
+	"asteroid/assets/waves"
+	"asteroid/config"
+	"asteroid/sprite"
+	"asteroid/storage"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// DefaultName is the level played unless another one is picked.
+const DefaultName = "default"
+
+// extensions are tried in order when looking for the file of a level.
+var extensions = []string{".yaml", ".yml", ".json"}
+
+// Level is a scripted sequence of waves.
+type Level struct {
+	Name  string
+	Waves []sprite.Wave
+}
+
+// replaySpeedup is how much faster the last wave gets every time it is replayed.
+const replaySpeedup = 1.1
+
+// Schedule returns wave n of the level, counting from 1. Past the last wave the
+// last one is replayed, its asteroids a tenth faster every time.
+func (l *Level) Schedule(n int) sprite.Wave {
+	if n <= len(l.Waves) {
+		return l.Waves[max(n, 1)-1]
+	}
+
+	w := l.Waves[len(l.Waves)-1]
+	f := math.Pow(replaySpeedup, float64(n-len(l.Waves)))
+	w.MinSpeed *= f
+	w.MaxSpeed *= f
+	w.Events = append([]sprite.SpawnEvent(nil), w.Events...)
+	for i := range w.Events {
+		w.Events[i].MinSpeed *= f
+		w.Events[i].MaxSpeed *= f
+	}
+	return w
+}
+
+// Loader finds levels in Dir before the embedded defaults, so a file in Dir
+// overrides the default level of the same name. Levels are parsed with the
+// settings of Asteroids.
+type Loader struct {
+	Dir       string
+	Defaults  fs.FS
+	Asteroids config.Asteroid
+}
+
+// NewLoader creates a Loader looking in dir, which may be empty, before the
+// levels embedded in the game.
+func NewLoader(dir string, asteroids config.Asteroid) *Loader {
+	return &Loader{Dir: dir, Defaults: waves.FS, Asteroids: asteroids}
+}
+
+// DefaultDir returns the override directory under the user's config directory.
+func DefaultDir() (string, error) {
+	return storage.Path("waves")
+}
+
+// Load reads and validates the level called name.
+func (l *Loader) Load(name string) (*Level, error) {
+	for _, ext := range extensions {
+		if l.Dir == "" {
+			break
+		}
+		path := filepath.Join(l.Dir, name+ext)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return l.parse(name, path, data)
+	}
+
+	for _, ext := range extensions {
+		data, err := fs.ReadFile(l.Defaults, name+ext)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return l.parse(name, name+ext, data)
+	}
+	return nil, fmt.Errorf("level %q not found", name)
+}
+
+func (l *Loader) parse(name, file string, data []byte) (*Level, error) {
+	lvl, err := Parse(file, data, l.Asteroids)
+	if err != nil {
+		return nil, err
+	}
+	lvl.Name = name
+	return lvl, nil
+}
@@ -0,0 +1,76 @@
+package level_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/level"
+	"asteroid/sprite"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	assert := assert.New(t)
+	lvl, err := level.NewLoader("", config.Default().Asteroid).Load(level.DefaultName)
+	assert.NoError(err, "the embedded default level should be valid")
+	assert.Equal(level.DefaultName, lvl.Name)
+	assert.NotEmpty(lvl.Waves)
+
+	for _, kinds := range []int{1, 2, 5} {
+		asteroids := config.Default().Asteroid
+		asteroids.Kinds = kinds
+		_, err := level.NewLoader("", asteroids).Load(level.DefaultName)
+		assert.NoError(err, "the default level is played with %d asteroid sizes", kinds)
+	}
+}
+
+func TestLoadOverride(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	loader := &level.Loader{
+		Dir:       dir,
+		Asteroids: config.Default().Asteroid,
+		Defaults: fstest.MapFS{
+			"default.yaml": {Data: []byte("waves: [{count: 1, spawn_rate: 1s}]")},
+			"other.yaml":   {Data: []byte("waves: [{count: 2, spawn_rate: 1s}]")},
+		},
+	}
+	assert.NoError(os.WriteFile(filepath.Join(dir, "default.json"), []byte(`{"waves": [{"count": 9, "spawn_rate": "1s"}]}`), 0o644))
+
+	lvl, err := loader.Load("default")
+	assert.NoError(err)
+	assert.Equal(9, lvl.Waves[0].Count, "the override directory comes first")
+
+	lvl, err = loader.Load("other")
+	assert.NoError(err)
+	assert.Equal(2, lvl.Waves[0].Count)
+
+	_, err = loader.Load("missing")
+	assert.ErrorContains(err, `level "missing" not found`)
+
+	assert.NoError(os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("waves: [{count: -1}]"), 0o644))
+	_, err = loader.Load("bad")
+	assert.ErrorContains(err, filepath.Join(dir, "bad.yaml")+":1:")
+}
+
+func TestLevelSchedule(t *testing.T) {
+	assert := assert.New(t)
+	lvl := &level.Level{Waves: []sprite.Wave{
+		{Count: 1, MinSpeed: 10, MaxSpeed: 20},
+		{Count: 2, MinSpeed: 100, MaxSpeed: 200, Events: []sprite.SpawnEvent{{MinSpeed: 50, MaxSpeed: 60}}},
+	}}
+
+	assert.Equal(lvl.Waves[0], lvl.Schedule(1))
+	assert.Equal(lvl.Waves[1], lvl.Schedule(2))
+
+	w := lvl.Schedule(4)
+	assert.Equal(2, w.Count, "the last wave is replayed")
+	assert.InDelta(121, w.MinSpeed, 0.0001)
+	assert.InDelta(242, w.MaxSpeed, 0.0001)
+	assert.InDelta(60.5, w.Events[0].MinSpeed, 0.0001)
+	assert.Equal(50.0, lvl.Waves[1].Events[0].MinSpeed, "replays do not change the level")
+}
@@ -0,0 +1,327 @@
+package level
+
+import (
+	"asteroid/config"
+	"asteroid/sprite"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error is a problem found in a wave file, pointing at the line it is on.
+type Error struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+var edgeNames = map[string]int{
+	"top":    sprite.EdgeTop,
+	"right":  sprite.EdgeRight,
+	"bottom": sprite.EdgeBottom,
+	"left":   sprite.EdgeLeft,
+	"random": sprite.EdgeRandom,
+}
+
+var formationNames = map[string]int{
+	"scatter": int(sprite.FormationScatter),
+	"line":    int(sprite.FormationLine),
+	"fan":     int(sprite.FormationFan),
+}
+
+// defaultFanSpacing is the angle between the asteroids of a fan, in degrees.
+const defaultFanSpacing = 15
+
+// Parse reads the waves of a level from a YAML or JSON document. file names
+// the document in errors. Speeds left out of the document and the sizes
+// asteroids come in are those of asteroids. Every problem found is reported,
+// each as an *Error with the line it is on.
+func Parse(file string, data []byte, asteroids config.Asteroid) (*Level, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	p := &parser{file: file, asteroids: asteroids}
+	waves := p.level(&root)
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+	return &Level{Waves: waves}, nil
+}
+
+// parser walks the nodes of a document, collecting every error on the way.
+type parser struct {
+	file      string
+	asteroids config.Asteroid
+	errs      []error
+}
+
+func (p *parser) fail(n *yaml.Node, format string, args ...any) {
+	p.errs = append(p.errs, &Error{File: p.file, Line: n.Line, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) level(root *yaml.Node) []sprite.Wave {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		p.errs = append(p.errs, &Error{File: p.file, Line: 1, Msg: "empty document"})
+		return nil
+	}
+	fields := p.mapping(root.Content[0], "waves")
+	if fields == nil {
+		return nil
+	}
+
+	list, ok := fields["waves"]
+	if !ok {
+		p.fail(root.Content[0], "missing waves")
+		return nil
+	}
+	items := p.sequence(list)
+	if items != nil && len(items) == 0 {
+		p.fail(list, "a level needs at least one wave")
+	}
+
+	var waves []sprite.Wave
+	for _, n := range items {
+		waves = append(waves, p.wave(n))
+	}
+	return waves
+}
+
+func (p *parser) wave(n *yaml.Node) sprite.Wave {
+	w := sprite.Wave{
+		MinSpeed: p.asteroids.MinSpeed,
+		MaxSpeed: p.asteroids.MaxSpeed,
+	}
+	fields := p.mapping(n, "count", "spawn_rate", "sizes", "speed", "events")
+	if fields == nil {
+		return w
+	}
+
+	if v, ok := fields["count"]; ok {
+		w.Count = p.int(v, 0)
+	}
+	if v, ok := fields["spawn_rate"]; ok {
+		var ok bool
+		if w.SpawnRate, ok = p.duration(v); ok && w.SpawnRate <= 0 {
+			p.fail(v, "spawn_rate must be positive")
+		}
+	} else if w.Count > 0 {
+		p.fail(n, "a wave with a count needs a spawn_rate")
+	}
+	if v, ok := fields["sizes"]; ok {
+		for _, s := range p.sequence(v) {
+			w.Sizes = append(w.Sizes, p.int(s, 0))
+		}
+		// a level written for more sizes than there are spawns the larger
+		// asteroids as the largest there is
+		if kinds := p.asteroids.Kinds; len(w.Sizes) > kinds {
+			for _, s := range w.Sizes[kinds:] {
+				w.Sizes[kinds-1] += s
+			}
+			w.Sizes = w.Sizes[:kinds]
+		}
+	}
+	if v, ok := fields["speed"]; ok {
+		w.MinSpeed, w.MaxSpeed = p.speed(v)
+	}
+	if v, ok := fields["events"]; ok {
+		for _, e := range p.sequence(v) {
+			w.Events = append(w.Events, p.event(e))
+		}
+		slices.SortStableFunc(w.Events, func(a, b sprite.SpawnEvent) int {
+			return cmp.Compare(a.At, b.At)
+		})
+	}
+
+	if w.Count == 0 && len(w.Events) == 0 {
+		p.fail(n, "a wave needs a count or events")
+	}
+	return w
+}
+
+func (p *parser) event(n *yaml.Node) sprite.SpawnEvent {
+	e := sprite.SpawnEvent{Edge: sprite.EdgeRandom, Count: 1}
+	fields := p.mapping(n, "at", "edge", "size", "count", "speed", "formation", "spacing")
+	if fields == nil {
+		return e
+	}
+
+	if v, ok := fields["at"]; ok {
+		var ok bool
+		if e.At, ok = p.duration(v); ok && e.At < 0 {
+			p.fail(v, "at must not be negative")
+		}
+	}
+	if v, ok := fields["edge"]; ok {
+		e.Edge = p.enum(v, edgeNames)
+	}
+	if v, ok := fields["size"]; ok {
+		e.Size = p.size(v)
+	}
+	if v, ok := fields["count"]; ok {
+		e.Count = p.int(v, 1)
+	}
+	if v, ok := fields["speed"]; ok {
+		e.MinSpeed, e.MaxSpeed = p.speed(v)
+	}
+	if v, ok := fields["formation"]; ok {
+		e.Formation = sprite.Formation(p.enum(v, formationNames))
+	}
+
+	switch e.Formation {
+	case sprite.FormationLine:
+		// a little more than the width of the largest asteroid
+		e.Spacing = 2.5 * float64(p.asteroids.MaxRadius())
+	case sprite.FormationFan:
+		e.Spacing = defaultFanSpacing
+	}
+	if v, ok := fields["spacing"]; ok {
+		e.Spacing = p.float(v)
+		if e.Spacing < 0 {
+			p.fail(v, "spacing must not be negative")
+		}
+	}
+	return e
+}
+
+// speed reads a {min, max} range of speeds.
+func (p *parser) speed(n *yaml.Node) (min, max float64) {
+	fields := p.mapping(n, "min", "max")
+	if fields == nil {
+		return 0, 0
+	}
+	for _, k := range []string{"min", "max"} {
+		if _, ok := fields[k]; !ok {
+			p.fail(n, "speed needs a %s", k)
+			return 0, 0
+		}
+	}
+
+	min, max = p.float(fields["min"]), p.float(fields["max"])
+	if min < 0 {
+		p.fail(fields["min"], "speed min must not be negative")
+	}
+	if max < min {
+		p.fail(fields["max"], "speed max %g is below min %g", max, min)
+	}
+	if max == 0 {
+		p.fail(fields["max"], "speed max must be positive")
+	}
+	return min, max
+}
+
+// size decodes the size of the asteroids of an event: small, medium or large,
+// which are the smallest, the middle and the largest of the sizes there are, or
+// a size counted from 1 for the smallest.
+func (p *parser) size(n *yaml.Node) int {
+	kinds := p.asteroids.Kinds
+	if n.Kind == yaml.ScalarNode {
+		switch n.Value {
+		case "small":
+			return 1
+		case "medium":
+			return (kinds + 1) / 2
+		case "large":
+			return kinds
+		}
+	}
+
+	var v int
+	if n.Kind != yaml.ScalarNode || n.Decode(&v) != nil {
+		p.fail(n, "expected small, medium, large or a size from 1 to %d, got %q", kinds, n.Value)
+		return 0
+	}
+	if v < 1 || v > kinds {
+		p.fail(n, "size %d is not one of the %d asteroid sizes", v, kinds)
+		return 0
+	}
+	return v
+}
+
+// mapping returns the values of a mapping node by key, reporting keys which
+// are not one of allowed. It returns nil if n is not a mapping.
+func (p *parser) mapping(n *yaml.Node, allowed ...string) map[string]*yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		p.fail(n, "expected a mapping")
+		return nil
+	}
+
+	fields := make(map[string]*yaml.Node, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		switch {
+		case !slices.Contains(allowed, k.Value):
+			p.fail(k, "unknown field %q", k.Value)
+		case fields[k.Value] != nil:
+			p.fail(k, "duplicate field %q", k.Value)
+		default:
+			fields[k.Value] = v
+		}
+	}
+	return fields
+}
+
+func (p *parser) sequence(n *yaml.Node) []*yaml.Node {
+	if n.Kind != yaml.SequenceNode {
+		p.fail(n, "expected a list")
+		return nil
+	}
+	return n.Content
+}
+
+// int decodes an integer of at least min.
+func (p *parser) int(n *yaml.Node, min int) int {
+	var v int
+	if err := n.Decode(&v); err != nil {
+		p.fail(n, "expected an integer, got %q", n.Value)
+		return min
+	}
+	if v < min {
+		p.fail(n, "%d is below the minimum of %d", v, min)
+		return min
+	}
+	return v
+}
+
+func (p *parser) float(n *yaml.Node) float64 {
+	var v float64
+	if err := n.Decode(&v); err != nil {
+		p.fail(n, "expected a number, got %q", n.Value)
+	}
+	return v
+}
+
+// duration decodes a duration such as "1.5s" or "200ms". It returns false if
+// n is not one.
+func (p *parser) duration(n *yaml.Node) (time.Duration, bool) {
+	d, err := time.ParseDuration(n.Value)
+	if n.Kind != yaml.ScalarNode || err != nil {
+		p.fail(n, "expected a duration such as \"1.5s\", got %q", n.Value)
+		return 0, false
+	}
+	return d, true
+}
+
+// enum decodes one of the names of values.
+func (p *parser) enum(n *yaml.Node, values map[string]int) int {
+	if v, ok := values[n.Value]; ok && n.Kind == yaml.ScalarNode {
+		return v
+	}
+
+	names := make([]string, 0, len(values))
+	for k := range values {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	p.fail(n, "expected one of %v, got %q", names, n.Value)
+	return 0
+}
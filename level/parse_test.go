@@ -0,0 +1,137 @@
+package level_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/level"
+	"asteroid/sprite"
+)
+
+func TestParseYAML(t *testing.T) {
+	assert := assert.New(t)
+	lvl, err := level.Parse("test.yaml", []byte(`
+waves:
+  - count: 4
+    spawn_rate: 500ms
+    sizes: [1, 2]
+    speed: {min: 30, max: 60}
+    events:
+      - at: 3s
+        edge: left
+        size: large
+        count: 2
+        formation: line
+        spacing: 150
+      - at: 1s
+        formation: fan
+`), config.Default().Asteroid)
+	assert.NoError(err)
+	assert.Equal([]sprite.Wave{{
+		Count:     4,
+		SpawnRate: 500 * time.Millisecond,
+		Sizes:     []int{1, 2},
+		MinSpeed:  30,
+		MaxSpeed:  60,
+		Events: []sprite.SpawnEvent{
+			{At: time.Second, Edge: sprite.EdgeRandom, Count: 1, Formation: sprite.FormationFan, Spacing: 15},
+			{At: 3 * time.Second, Edge: sprite.EdgeLeft, Size: 3, Count: 2, Formation: sprite.FormationLine, Spacing: 150},
+		},
+	}}, lvl.Waves)
+}
+
+func TestParseJSON(t *testing.T) {
+	assert := assert.New(t)
+	lvl, err := level.Parse("test.json", []byte(`{
+  "waves": [
+    {"count": 2, "spawn_rate": "1s"},
+    {"events": [{"edge": "bottom", "count": 3, "speed": {"min": 80, "max": 90}}]}
+  ]
+}`), config.Default().Asteroid)
+	assert.NoError(err)
+	if assert.Len(lvl.Waves, 2) {
+		assert.Equal(2, lvl.Waves[0].Count)
+		assert.Equal(40.0, lvl.Waves[0].MinSpeed, "waves default to the built-in speeds")
+		assert.Equal(sprite.SpawnEvent{Edge: sprite.EdgeBottom, Count: 3, MinSpeed: 80, MaxSpeed: 90}, lvl.Waves[1].Events[0])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	assert := assert.New(t)
+	_, err := level.Parse("bad.yaml", []byte(`waves:
+  - count: 3
+    sizes: [1, 1, 1, 1]
+  - events:
+      - at: soon
+        edge: up
+        count: 0
+        speed: {min: 90, max: 30}
+        formaton: line
+        size: 4
+  - {}
+`), config.Default().Asteroid)
+	assert.Error(err)
+
+	var lines []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var le *level.Error
+		if assert.True(errors.As(e, &le)) {
+			assert.Equal("bad.yaml", le.File)
+			lines = append(lines, le.Line)
+		}
+	}
+	slices.Sort(lines)
+	assert.Equal([]int{2, 5, 6, 7, 8, 9, 10, 11}, lines, "every problem is reported")
+	assert.Contains(err.Error(), `bad.yaml:6: expected one of [bottom left random right top], got "up"`)
+	assert.Contains(err.Error(), `bad.yaml:9: unknown field "formaton"`)
+	assert.Contains(err.Error(), `bad.yaml:10: size 4 is not one of the 3 asteroid sizes`)
+	assert.Contains(err.Error(), `bad.yaml:11: a wave needs a count or events`)
+}
+
+func TestParseSizes(t *testing.T) {
+	assert := assert.New(t)
+	asteroids := config.Default().Asteroid
+	asteroids.Kinds = 2
+	lvl, err := level.Parse("sizes.yaml", []byte(`
+waves:
+  - count: 4
+    spawn_rate: 1s
+    sizes: [2, 2, 1]
+    events:
+      - size: small
+      - size: medium
+      - size: large
+      - size: 2
+`), asteroids)
+	assert.NoError(err)
+	assert.Equal([]int{2, 3}, lvl.Waves[0].Sizes, "weights beyond the sizes there are go to the largest")
+	var sizes []int
+	for _, e := range lvl.Waves[0].Events {
+		sizes = append(sizes, e.Size)
+	}
+	assert.Equal([]int{1, 1, 2, 2}, sizes)
+
+	_, err = level.Parse("sizes.yaml", []byte(`
+waves:
+  - events:
+      - size: 3
+      - size: huge
+`), asteroids)
+	assert.ErrorContains(err, "sizes.yaml:4: size 3 is not one of the 2 asteroid sizes")
+	assert.ErrorContains(err, `sizes.yaml:5: expected small, medium, large or a size from 1 to 2, got "huge"`)
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	assert := assert.New(t)
+	_, err := level.Parse("broken.json", []byte("{\"waves\": [\n  {\"count\": 1,\n]}"), config.Default().Asteroid)
+	assert.ErrorContains(err, "broken.json")
+	assert.ErrorContains(err, "line 2")
+
+	_, err = level.Parse("empty.yaml", nil, config.Default().Asteroid)
+	assert.ErrorContains(err, "empty.yaml:1: empty document")
+}
@@ -1,20 +1,106 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
-	"asteroid/constant"
+	"asteroid/config"
 	"asteroid/game"
+	"asteroid/highscore"
+	"asteroid/input"
+	"asteroid/level"
+	"asteroid/replay"
+	"asteroid/simulation"
+	"asteroid/sprite"
 )
 
 var g *game.Game
 
+// configPollInterval is how often the settings file is checked for edits.
+const configPollInterval = 500 * time.Millisecond
+
 func main() {
-	ebiten.SetWindowSize(constant.SCREEN_WIDTH, constant.SCREEN_HEIGHT)
+	seed := flag.Uint64("seed", 0, "seed of the asteroid field, 0 picks a random one on every game")
+	mode := flag.String("mode", simulation.ModeArcade, "game mode, arcade or classic (screen wrap)")
+	levelName := flag.String("level", level.DefaultName, "wave script to play")
+	levelDir := flag.String("level-dir", "", "directory of wave scripts overriding the built-in ones (default under the user config directory)")
+	replayPath := flag.String("replay", "", "replay file to play back, games are recorded under the user config directory")
+	workers := flag.Int("workers", 0, "goroutines moving large numbers of asteroids and bullets, 0 for none and -1 for one per CPU")
+	src, err := config.ParseFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg, err := src.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var rec *replay.Recording
+	if *replayPath != "" {
+		if rec, err = replay.Load(*replayPath); err != nil {
+			log.Fatal(err)
+		}
+		// the window fits the recorded game
+		cfg.Screen = rec.Config.Screen
+	}
+
+	rules, err := simulation.RulesForMode(*mode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *levelDir == "" {
+		if dir, err := level.DefaultDir(); err == nil {
+			*levelDir = dir
+		}
+	}
+	lvl, err := level.NewLoader(*levelDir, cfg.Asteroid).Load(*levelName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rules.Waves = lvl.Schedule
+
+	ebiten.SetWindowSize(cfg.Screen.Width, cfg.Screen.Height)
 	ebiten.SetWindowTitle("Geometry Matrix")
-	g = game.NewGame()
+	ebiten.SetTPS(sprite.TPS)
+
+	var store *highscore.Store
+	if path, err := highscore.DefaultPath(); err != nil {
+		log.Printf("high scores will not be saved: %v", err)
+	} else {
+		store = highscore.NewStore(path, highscore.DefaultSize)
+	}
+	g = game.NewGame(cfg, *seed, rules, store)
+	if path, err := input.DefaultPath(); err != nil {
+		log.Printf("controls will not be saved: %v", err)
+	} else {
+		g.UseControls(input.NewStore(path))
+	}
+	if dir, err := replay.DefaultDir(); err != nil {
+		log.Printf("replays will not be saved: %v", err)
+	} else {
+		g.UseReplays(replay.NewStore(dir, replay.DefaultKeep))
+	}
+	if dir, err := simulation.DefaultSaveDir(); err != nil {
+		log.Printf("games can not be saved: %v", err)
+	} else {
+		g.UseSaves(dir)
+	}
+	g.WatchConfig(config.NewWatcher(src, configPollInterval))
+	if *workers != 0 {
+		pool := simulation.NewPool(*workers)
+		defer pool.Close()
+		g.UsePool(pool)
+	}
+	if rec != nil {
+		if err := g.PlayReplay(rec); err != nil {
+			log.Fatal(err)
+		}
+	}
 	if err := ebiten.RunGame(g); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,170 @@
+package physics
+
+import (
+	"fmt"
+	"math"
+
+	"asteroid/utils"
+)
+
+// Shape is a hitbox which can be tested for overlap with Overlaps. It is
+// sealed: Circle and Polygon, or pointers to them, are the only shapes, so
+// none can slip past Overlaps without a test.
+type Shape interface {
+	// BoundingCircle returns a circle enclosing the shape, for the broad phase.
+	BoundingCircle() (utils.Vector2, float64)
+	shape()
+}
+
+type Circle struct {
+	Center utils.Vector2
+	Radius float64
+}
+
+func (c Circle) BoundingCircle() (utils.Vector2, float64) {
+	return c.Center, c.Radius
+}
+
+func (Circle) shape() {}
+
+// Polygon is a convex polygon. Its points may be in either winding order.
+type Polygon struct {
+	Points []utils.Vector2
+}
+
+// BoundingCircle returns the circle around the centroid of the polygon
+// reaching its farthest point.
+func (p Polygon) BoundingCircle() (utils.Vector2, float64) {
+	var c utils.Vector2
+	for _, v := range p.Points {
+		c.Add(v)
+	}
+	if len(p.Points) > 0 {
+		c.Scale(1 / float64(len(p.Points)))
+	}
+
+	r := 0.0
+	for _, v := range p.Points {
+		r = utils.Max(r, utils.Distance(c.X, c.Y, v.X, v.Y))
+	}
+	return c, r
+}
+
+func (Polygon) shape() {}
+
+// Contains reports whether q lies inside the polygon or on its boundary.
+func (p Polygon) Contains(q utils.Vector2) bool {
+	n := len(p.Points)
+	if n < 3 {
+		return false
+	}
+
+	pos, neg := false, false
+	for i := range n {
+		a, b := p.Points[i], p.Points[(i+1)%n]
+		c := cross(*b.Clone().Sub(a), *q.Clone().Sub(a))
+		pos = pos || c > 0
+		neg = neg || c < 0
+	}
+	return !(pos && neg)
+}
+
+// Overlaps reports whether two shapes touch or intersect. It panics on a nil
+// shape.
+func Overlaps(a, b Shape) bool {
+	a, b = value(a), value(b)
+	switch a := a.(type) {
+	case Circle:
+		switch b := b.(type) {
+		case Circle:
+			return circleCircle(a, b)
+		case Polygon:
+			return circlePolygon(a, b)
+		}
+	case Polygon:
+		switch b := b.(type) {
+		case Circle:
+			return circlePolygon(b, a)
+		case Polygon:
+			return polygonPolygon(a, b)
+		}
+	}
+	panic(fmt.Sprintf("physics: no overlap test for %T and %T", a, b))
+}
+
+// value returns the shape s points to, if it is a pointer.
+func value(s Shape) Shape {
+	switch s := s.(type) {
+	case *Circle:
+		return *s
+	case *Polygon:
+		return *s
+	}
+	return s
+}
+
+// ClosestPointOnSegment returns the point of the segment ab closest to p.
+func ClosestPointOnSegment(p, a, b utils.Vector2) utils.Vector2 {
+	ab := *b.Clone().Sub(a)
+	l := dot(ab, ab)
+	if l == 0 {
+		return a
+	}
+	t := utils.Clamp(dot(*p.Clone().Sub(a), ab)/l, 0, 1)
+	return *a.Clone().Add(*ab.Scale(t))
+}
+
+func circleCircle(a, b Circle) bool {
+	return utils.Distance(a.Center.X, a.Center.Y, b.Center.X, b.Center.Y) <= a.Radius+b.Radius
+}
+
+func circlePolygon(c Circle, p Polygon) bool {
+	if p.Contains(c.Center) {
+		return true
+	}
+
+	n := len(p.Points)
+	for i := range n {
+		q := ClosestPointOnSegment(c.Center, p.Points[i], p.Points[(i+1)%n])
+		if utils.Distance(c.Center.X, c.Center.Y, q.X, q.Y) <= c.Radius {
+			return true
+		}
+	}
+	return false
+}
+
+// polygonPolygon is a separating axis test over the edge normals of both polygons.
+func polygonPolygon(a, b Polygon) bool {
+	for _, p := range [2]Polygon{a, b} {
+		n := len(p.Points)
+		for i := range n {
+			edge := *p.Points[(i+1)%n].Clone().Sub(p.Points[i])
+			axis := utils.Vector2{X: -edge.Y, Y: edge.X}
+
+			minA, maxA := project(a, axis)
+			minB, maxB := project(b, axis)
+			if maxA < minB || maxB < minA {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func project(p Polygon, axis utils.Vector2) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, v := range p.Points {
+		d := dot(v, axis)
+		min = utils.Min(min, d)
+		max = utils.Max(max, d)
+	}
+	return min, max
+}
+
+func dot(a, b utils.Vector2) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+func cross(a, b utils.Vector2) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
@@ -0,0 +1,79 @@
+package physics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/physics"
+	"asteroid/utils"
+)
+
+func square(x, y, size float64) physics.Polygon {
+	return physics.Polygon{Points: []utils.Vector2{
+		{X: x, Y: y},
+		{X: x + size, Y: y},
+		{X: x + size, Y: y + size},
+		{X: x, Y: y + size},
+	}}
+}
+
+func TestPolygonBoundingCircle(t *testing.T) {
+	assert := assert.New(t)
+	c, r := square(0, 0, 2).BoundingCircle()
+	assert.Equal(utils.Vector2{X: 1, Y: 1}, c)
+	assert.InDelta(1.41421, r, 0.0001)
+}
+
+func TestPolygonContains(t *testing.T) {
+	assert := assert.New(t)
+	sq := square(0, 0, 10)
+	assert.True(sq.Contains(utils.Vector2{X: 5, Y: 5}))
+	assert.True(sq.Contains(utils.Vector2{X: 10, Y: 5}), "boundary is inside")
+	assert.False(sq.Contains(utils.Vector2{X: 11, Y: 5}))
+
+	// winding order does not matter
+	rev := physics.Polygon{Points: []utils.Vector2{{X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0}}}
+	assert.True(rev.Contains(utils.Vector2{X: 5, Y: 5}))
+}
+
+func TestClosestPointOnSegment(t *testing.T) {
+	assert := assert.New(t)
+	a, b := utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 10, Y: 0}
+	assert.Equal(utils.Vector2{X: 5, Y: 0}, physics.ClosestPointOnSegment(utils.Vector2{X: 5, Y: 5}, a, b))
+	assert.Equal(a, physics.ClosestPointOnSegment(utils.Vector2{X: -5, Y: 5}, a, b))
+	assert.Equal(b, physics.ClosestPointOnSegment(utils.Vector2{X: 15, Y: -5}, a, b))
+	assert.Equal(a, physics.ClosestPointOnSegment(utils.Vector2{X: 15, Y: -5}, a, a))
+}
+
+func TestOverlaps(t *testing.T) {
+	triangle := physics.Polygon{Points: []utils.Vector2{{X: 100, Y: 80}, {X: 113, Y: 120}, {X: 87, Y: 120}}}
+
+	cases := []struct {
+		name string
+		a, b physics.Shape
+		want bool
+	}{
+		{"circles apart", physics.Circle{Radius: 5}, physics.Circle{Center: utils.Vector2{X: 20}, Radius: 5}, false},
+		{"circles touching", physics.Circle{Radius: 5}, physics.Circle{Center: utils.Vector2{X: 10}, Radius: 5}, true},
+		{"circle inside polygon", physics.Circle{Center: utils.Vector2{X: 5, Y: 5}, Radius: 1}, square(0, 0, 10), true},
+		{"circle crossing edge", physics.Circle{Center: utils.Vector2{X: 12, Y: 5}, Radius: 3}, square(0, 0, 10), true},
+		{"circle near corner", physics.Circle{Center: utils.Vector2{X: 12, Y: 12}, Radius: 2}, square(0, 0, 10), false},
+		{"circle beside triangle", physics.Circle{Center: utils.Vector2{X: 130, Y: 100}, Radius: 10}, triangle, false},
+		{"polygon and circle", triangle, physics.Circle{Center: utils.Vector2{X: 100, Y: 70}, Radius: 10}, true},
+		{"polygons overlapping", square(0, 0, 10), square(5, 5, 10), true},
+		{"polygons apart", square(0, 0, 10), square(11, 0, 10), false},
+		{"polygons apart diagonally", triangle, square(110, 80, 10), false},
+		{"pointers to shapes", &physics.Circle{Center: utils.Vector2{X: 5, Y: 5}, Radius: 1}, &triangle, false},
+		{"pointer to a shape touching", &physics.Circle{Center: utils.Vector2{X: 100, Y: 70}, Radius: 10}, &triangle, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, physics.Overlaps(c.a, c.b))
+			assert.Equal(t, c.want, physics.Overlaps(c.b, c.a))
+		})
+	}
+
+	assert.Panics(t, func() { physics.Overlaps(nil, triangle) }, "a missing hitbox is a bug, not a miss")
+}
@@ -0,0 +1,99 @@
+package physics
+
+import (
+	"math"
+	"slices"
+
+	"asteroid/utils"
+)
+
+// bucketCount is the number of hash buckets, a power of two. Cells sharing a
+// bucket only cost extra candidates, never missed ones.
+const bucketCount = 4096
+
+// SpatialHash is a uniform grid broad phase. Items are inserted with their
+// bounding circle and Query returns the items sharing a cell with a circle,
+// which are the only candidates that may collide with it.
+//
+// It is meant to be cleared and rebuilt every tick.
+type SpatialHash struct {
+	CellSize float64
+
+	buckets [][]int
+	// stamps dedupes items spanning several cells during a query.
+	stamps []uint32
+	stamp  uint32
+}
+
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	return &SpatialHash{
+		CellSize: cellSize,
+		buckets:  make([][]int, bucketCount),
+	}
+}
+
+// Clear removes every item while keeping the allocated cells for reuse.
+func (h *SpatialHash) Clear() {
+	for i := range h.buckets {
+		h.buckets[i] = h.buckets[i][:0]
+	}
+}
+
+// Insert adds the item id with the bounding circle at center with radius.
+// Ids are expected to be small non-negative integers such as slice indices.
+func (h *SpatialHash) Insert(id int, center utils.Vector2, radius float64) {
+	minX, minY, maxX, maxY := h.span(center, radius)
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			k := bucket(x, y)
+			h.buckets[k] = append(h.buckets[k], id)
+		}
+	}
+	if id >= len(h.stamps) {
+		h.stamps = append(h.stamps, make([]uint32, id-len(h.stamps)+1)...)
+	}
+}
+
+// Query appends to dst the ids of the items that may overlap the circle at
+// center with radius. The ids are unique and sorted in ascending order, so the
+// result does not depend on the layout of the grid.
+func (h *SpatialHash) Query(center utils.Vector2, radius float64, dst []int) []int {
+	h.stamp++
+	if h.stamp == 0 {
+		clear(h.stamps)
+		h.stamp = 1
+	}
+
+	start := len(dst)
+	minX, minY, maxX, maxY := h.span(center, radius)
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			for _, id := range h.buckets[bucket(x, y)] {
+				if h.stamps[id] == h.stamp {
+					continue
+				}
+				h.stamps[id] = h.stamp
+				dst = append(dst, id)
+			}
+		}
+	}
+	slices.Sort(dst[start:])
+	return dst
+}
+
+func (h *SpatialHash) span(center utils.Vector2, radius float64) (minX, minY, maxX, maxY int) {
+	minX = int(math.Floor((center.X - radius) / h.CellSize))
+	minY = int(math.Floor((center.Y - radius) / h.CellSize))
+	maxX = int(math.Floor((center.X + radius) / h.CellSize))
+	maxY = int(math.Floor((center.Y + radius) / h.CellSize))
+	return
+}
+
+func bucket(x, y int) int {
+	// splitmix64 finalizer over the packed cell coordinates
+	k := uint64(uint32(x))<<32 | uint64(uint32(y))
+	k = (k ^ k>>30) * 0xbf58476d1ce4e5b9
+	k = (k ^ k>>27) * 0x94d049bb133111eb
+	k ^= k >> 31
+	return int(k & (bucketCount - 1))
+}
@@ -0,0 +1,135 @@
+package physics_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/physics"
+	"asteroid/utils"
+)
+
+type body struct {
+	center utils.Vector2
+	radius float64
+}
+
+func (b body) overlaps(o body) bool {
+	return utils.Distance(b.center.X, b.center.Y, o.center.X, o.center.Y) <= b.radius+o.radius
+}
+
+func randomBodies(rng *rand.Rand, n int, radius float64) []body {
+	bodies := make([]body, n)
+	for i := range bodies {
+		bodies[i] = body{
+			center: utils.Vector2{X: rng.Float64() * 1280, Y: rng.Float64() * 720},
+			radius: radius,
+		}
+	}
+	return bodies
+}
+
+func TestSpatialHashQuery(t *testing.T) {
+	assert := assert.New(t)
+	h := physics.NewSpatialHash(100)
+
+	h.Insert(0, utils.Vector2{X: 50, Y: 50}, 10)
+	h.Insert(1, utils.Vector2{X: 150, Y: 50}, 60) // spans two cells
+	h.Insert(2, utils.Vector2{X: 550, Y: 550}, 10)
+	h.Insert(3, utils.Vector2{X: -50, Y: -50}, 10)
+
+	assert.Equal([]int{0, 1}, h.Query(utils.Vector2{X: 60, Y: 60}, 5, nil))
+	assert.Equal([]int{1}, h.Query(utils.Vector2{X: 150, Y: 50}, 5, nil))
+	assert.Equal([]int{2}, h.Query(utils.Vector2{X: 540, Y: 540}, 5, nil))
+	assert.Equal([]int{3}, h.Query(utils.Vector2{X: -10, Y: -10}, 5, nil))
+	assert.Empty(h.Query(utils.Vector2{X: 350, Y: 350}, 5, nil))
+
+	// candidates are appended to dst
+	assert.Equal([]int{9, 2}, h.Query(utils.Vector2{X: 540, Y: 540}, 5, []int{9}))
+
+	h.Clear()
+	assert.Empty(h.Query(utils.Vector2{X: 60, Y: 60}, 5, nil))
+}
+
+func TestSpatialHashMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	asteroids := randomBodies(rng, 500, 40)
+	bullets := randomBodies(rng, 200, 5)
+
+	h := physics.NewSpatialHash(120)
+	for i, a := range asteroids {
+		h.Insert(i, a.center, a.radius)
+	}
+
+	assert := assert.New(t)
+	var candidates []int
+	for _, b := range bullets {
+		var want, got []int
+		for j, a := range asteroids {
+			if b.overlaps(a) {
+				want = append(want, j)
+			}
+		}
+		candidates = h.Query(b.center, b.radius, candidates[:0])
+		for _, j := range candidates {
+			if b.overlaps(asteroids[j]) {
+				got = append(got, j)
+			}
+		}
+		assert.Equal(want, got)
+	}
+}
+
+var benchSizes = []int{100, 1000, 5000}
+
+func BenchmarkBruteForce(b *testing.B) {
+	for _, n := range benchSizes {
+		rng := rand.New(rand.NewPCG(1, 1))
+		asteroids := randomBodies(rng, n, 40)
+		bullets := randomBodies(rng, 100, 5)
+
+		b.Run(fmt.Sprintf("asteroids=%d", n), func(b *testing.B) {
+			hits := 0
+			for b.Loop() {
+				for _, bl := range bullets {
+					for _, a := range asteroids {
+						if bl.overlaps(a) {
+							hits++
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialHash(b *testing.B) {
+	for _, n := range benchSizes {
+		rng := rand.New(rand.NewPCG(1, 1))
+		asteroids := randomBodies(rng, n, 40)
+		bullets := randomBodies(rng, 100, 5)
+		h := physics.NewSpatialHash(120)
+
+		b.Run(fmt.Sprintf("asteroids=%d", n), func(b *testing.B) {
+			hits := 0
+			var candidates []int
+			for b.Loop() {
+				// rebuilt every iteration, as it is every tick
+				h.Clear()
+				for i, a := range asteroids {
+					h.Insert(i, a.center, a.radius)
+				}
+				for _, bl := range bullets {
+					candidates = h.Query(bl.center, bl.radius, candidates[:0])
+					for _, j := range candidates {
+						if bl.overlaps(asteroids[j]) {
+							hits++
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package physics
+
+import "asteroid/utils"
+
+// SegmentCircle reports whether the segment ab passes within the circle c.
+func SegmentCircle(a, b utils.Vector2, c Circle) bool {
+	q := ClosestPointOnSegment(c.Center, a, b)
+	return utils.Distance(c.Center.X, c.Center.Y, q.X, q.Y) <= c.Radius
+}
+
+// SweptCircles reports whether two circles moving linearly during a tick, the
+// first from a0 to a1 and the second from b0 to b1, touch at any time of the tick.
+// Unlike testing the end positions only, fast circles cannot tunnel through each other.
+func SweptCircles(a0, a1 utils.Vector2, ra float64, b0, b1 utils.Vector2, rb float64) bool {
+	// move in the frame of the second circle, where it stands still at the origin
+	rel0 := *a0.Clone().Sub(b0)
+	rel1 := *a1.Clone().Sub(b1)
+	return SegmentCircle(rel0, rel1, Circle{Radius: ra + rb})
+}
+
+// SweptBounds returns a circle enclosing a circle of radius r moving from p0 to p1.
+func SweptBounds(p0, p1 utils.Vector2, r float64) (utils.Vector2, float64) {
+	center := *p0.Clone().Add(p1).Scale(0.5)
+	return center, r + utils.Distance(p0.X, p0.Y, p1.X, p1.Y)/2
+}
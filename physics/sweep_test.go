@@ -0,0 +1,52 @@
+package physics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/physics"
+	"asteroid/utils"
+)
+
+func TestSegmentCircle(t *testing.T) {
+	assert := assert.New(t)
+	c := physics.Circle{Center: utils.Vector2{X: 50, Y: 0}, Radius: 5}
+
+	assert.True(physics.SegmentCircle(utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 100, Y: 0}, c), "passing through")
+	assert.True(physics.SegmentCircle(utils.Vector2{X: 0, Y: 5}, utils.Vector2{X: 100, Y: 5}, c), "grazing")
+	assert.False(physics.SegmentCircle(utils.Vector2{X: 0, Y: 6}, utils.Vector2{X: 100, Y: 6}, c), "passing by")
+	assert.False(physics.SegmentCircle(utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 40, Y: 0}, c), "stopping short")
+}
+
+func TestSweptCircles(t *testing.T) {
+	assert := assert.New(t)
+
+	// a fast bullet jumping over a small still target
+	assert.True(physics.SweptCircles(
+		utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 100, Y: 0}, 2,
+		utils.Vector2{X: 50, Y: 0}, utils.Vector2{X: 50, Y: 0}, 5,
+	))
+	// both moving in the same direction, never meeting
+	assert.False(physics.SweptCircles(
+		utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 100, Y: 0}, 2,
+		utils.Vector2{X: 50, Y: 0}, utils.Vector2{X: 150, Y: 0}, 5,
+	))
+	// crossing paths at the same time
+	assert.True(physics.SweptCircles(
+		utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 100, Y: 0}, 2,
+		utils.Vector2{X: 50, Y: -50}, utils.Vector2{X: 50, Y: 50}, 2,
+	))
+	// crossing paths at different times
+	assert.False(physics.SweptCircles(
+		utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 100, Y: 0}, 2,
+		utils.Vector2{X: 0, Y: -50}, utils.Vector2{X: 0, Y: 50}, 2,
+	))
+}
+
+func TestSweptBounds(t *testing.T) {
+	assert := assert.New(t)
+	c, r := physics.SweptBounds(utils.Vector2{X: 0, Y: 0}, utils.Vector2{X: 10, Y: 0}, 2)
+	assert.Equal(utils.Vector2{X: 5, Y: 0}, c)
+	assert.Equal(7.0, r)
+}
@@ -0,0 +1,36 @@
+package physics
+
+import (
+	"image"
+
+	"asteroid/utils"
+)
+
+// WrapOffsets appends to dst the translations at which a circle wrapping around
+// bounds shows up inside them: the zero translation first, then the images
+// across the edges it overlaps.
+func WrapOffsets(center utils.Vector2, radius float64, bounds image.Rectangle, dst []utils.Vector2) []utils.Vector2 {
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+
+	xs, nx := [2]float64{}, 1
+	switch {
+	case center.X-radius < float64(bounds.Min.X):
+		xs[nx], nx = w, nx+1
+	case center.X+radius > float64(bounds.Max.X):
+		xs[nx], nx = -w, nx+1
+	}
+	ys, ny := [2]float64{}, 1
+	switch {
+	case center.Y-radius < float64(bounds.Min.Y):
+		ys[ny], ny = h, ny+1
+	case center.Y+radius > float64(bounds.Max.Y):
+		ys[ny], ny = -h, ny+1
+	}
+
+	for _, y := range ys[:ny] {
+		for _, x := range xs[:nx] {
+			dst = append(dst, utils.Vector2{X: x, Y: y})
+		}
+	}
+	return dst
+}
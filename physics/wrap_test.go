@@ -0,0 +1,34 @@
+package physics_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/physics"
+	"asteroid/utils"
+)
+
+func TestWrapOffsets(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 50)
+
+	cases := []struct {
+		name   string
+		center utils.Vector2
+		want   []utils.Vector2
+	}{
+		{"inside", utils.Vector2{X: 50, Y: 25}, []utils.Vector2{{}}},
+		{"left edge", utils.Vector2{X: 5, Y: 25}, []utils.Vector2{{}, {X: 100}}},
+		{"right edge", utils.Vector2{X: 95, Y: 25}, []utils.Vector2{{}, {X: -100}}},
+		{"top edge", utils.Vector2{X: 50, Y: 5}, []utils.Vector2{{}, {Y: 50}}},
+		{"bottom edge", utils.Vector2{X: 50, Y: 45}, []utils.Vector2{{}, {Y: -50}}},
+		{"corner", utils.Vector2{X: 95, Y: 5}, []utils.Vector2{{}, {X: -100}, {Y: 50}, {X: -100, Y: 50}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, physics.WrapOffsets(c.center, 10, bounds, nil))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package render draws the entities of a world with ebiten. It is kept apart
+// from the simulation so the simulation runs on machines without a display.
+package render
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"asteroid/utils"
+)
+
+var (
+	whiteImage = ebiten.NewImage(3, 3)
+
+	// whiteSubImage is an internal sub image of whiteImage.
+	// Use whiteSubImage at DrawTriangles instead of whiteImage in order to avoid bleeding edges.
+	whiteSubImage = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	whiteImage.Fill(color.White)
+}
+
+// World draws the ship, unless it is waiting to respawn, the asteroids and the
+// bullets of w.
+func World(screen *ebiten.Image, w *simulation.World) {
+	if !w.IsRespawning() {
+		Player(screen, &w.Player)
+	}
+	Asteroids(screen, &w.AsteroidCtrl)
+	Bullets(screen, &w.BulletCtrl)
+}
+
+// Player draws the ship, blinking while it is invulnerable.
+func Player(screen *ebiten.Image, p *sprite.Player) {
+	if !p.IsVisible() {
+		return
+	}
+	p.ForEachImage(func(center utils.Vector2) {
+		var vertices []ebiten.Vertex
+		var indices []uint16
+		var path vector.Path
+
+		corners := p.TriangleAt(center)
+
+		path.MoveTo(float32(corners[0].X), float32(corners[0].Y)) // Top vertex
+		path.LineTo(float32(corners[1].X), float32(corners[1].Y)) // Bottom-left vertex
+		path.LineTo(float32(corners[2].X), float32(corners[2].Y)) // Bottom-right vertex
+		path.Close()
+
+		vertices, indices = path.AppendVerticesAndIndicesForFilling(vertices, indices)
+
+		op := &ebiten.DrawTrianglesOptions{}
+		op.AntiAlias = true
+		op.FillRule = ebiten.FillRuleNonZero
+		screen.DrawTriangles(vertices, indices, whiteSubImage, op)
+	})
+}
+
+// Asteroids draws the outline of every asteroid of c.
+func Asteroids(screen *ebiten.Image, c *sprite.AsteroidControl) {
+	for _, a := range c.Asteroids {
+		c.ForEachImage(a, func(center utils.Vector2) {
+			vector.StrokeCircle(screen, float32(center.X), float32(center.Y), float32(a.Radius), 2.0, color.White, true)
+		})
+	}
+}
+
+// Bullets draws every bullet of c.
+func Bullets(screen *ebiten.Image, c *sprite.BulletControl) {
+	for _, b := range c.Bullets {
+		c.ForEachImage(b, func(center utils.Vector2) {
+			vector.DrawFilledCircle(screen, float32(center.X), float32(center.Y), float32(b.Radius), color.White, true)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"asteroid/config"
+	"asteroid/control"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+// A replay file starts with magic and the version of its layout, followed by
+// the length of a JSON header and the header itself. The commands come last,
+// as runs of the same command: the length of the run, then the command as
+// encoded by control.Encode. Ships are usually steered the same way for many
+// ticks in a row, which keeps files small.
+const (
+	magic = "ASTR"
+
+	// fileVersion is bumped whenever the layout of the file changes.
+	fileVersion = 1
+
+	// maxHeader bounds the header, so a damaged length does not allocate the
+	// memory of the machine.
+	maxHeader = 16 << 20
+	// maxPrealloc bounds the commands allocated before they are read.
+	maxPrealloc = 1 << 20
+)
+
+// header is everything but the commands.
+type header struct {
+	Seed    uint64        `json:"seed"`
+	Mode    string        `json:"mode"`
+	Config  config.Config `json:"config"`
+	Waves   []sprite.Wave `json:"waves"`
+	Changes []Change      `json:"changes,omitempty"`
+	Ticks   int           `json:"ticks"`
+}
+
+// Write writes rec to w.
+func Write(w io.Writer, rec *Recording) error {
+	h, err := json.Marshal(header{
+		Seed:    rec.Seed,
+		Mode:    rec.Mode,
+		Config:  rec.Config,
+		Waves:   rec.Waves,
+		Changes: rec.Changes,
+		Ticks:   len(rec.Commands),
+	})
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.WriteByte(fileVersion)
+	bw.Write(binary.AppendUvarint(nil, uint64(len(h))))
+	bw.Write(h)
+
+	var buf []byte
+	for i := 0; i < len(rec.Commands); {
+		cmd := rec.Commands[i]
+		run := 1
+		for i+run < len(rec.Commands) && rec.Commands[i+run] == cmd {
+			run++
+		}
+		buf = binary.AppendUvarint(buf[:0], uint64(run))
+		buf = append(buf, control.Encode(cmd))
+		bw.Write(buf)
+		i += run
+	}
+	return bw.Flush()
+}
+
+// Read reads a recording written by Write.
+func Read(r io.Reader) (*Recording, error) {
+	br := bufio.NewReader(r)
+	head := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, head); err != nil || string(head[:len(magic)]) != magic {
+		return nil, errors.New("not a replay file")
+	}
+	if v := head[len(magic)]; v != fileVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", v)
+	}
+
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("read replay header: %w", noEOF(err))
+	}
+	if n > maxHeader {
+		return nil, fmt.Errorf("replay header of %d bytes is too large", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(br, data); err != nil {
+		return nil, fmt.Errorf("read replay header: %w", noEOF(err))
+	}
+	var h header
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("read replay header: %w", err)
+	}
+	if _, err := simulation.RulesForMode(h.Mode); err != nil {
+		return nil, fmt.Errorf("replay mode: %w", err)
+	}
+	if err := h.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("replay settings: %w", err)
+	}
+	// the changes are applied to the world as they are
+	for i, c := range h.Changes {
+		if err := c.Config.Validate(); err != nil {
+			return nil, fmt.Errorf("replay settings change %d at tick %d: %w", i, c.Tick, err)
+		}
+	}
+	if h.Ticks < 0 {
+		return nil, fmt.Errorf("replay of %d ticks", h.Ticks)
+	}
+
+	rec := &Recording{
+		Seed:     h.Seed,
+		Mode:     h.Mode,
+		Config:   h.Config,
+		Waves:    h.Waves,
+		Changes:  h.Changes,
+		Commands: make([]sprite.Command, 0, min(h.Ticks, maxPrealloc)),
+	}
+	for len(rec.Commands) < h.Ticks {
+		run, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("read replay commands: %w", noEOF(err))
+		}
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("read replay commands: %w", noEOF(err))
+		}
+		if run == 0 || run > uint64(h.Ticks-len(rec.Commands)) {
+			return nil, fmt.Errorf("replay command run of %d ticks is out of range", run)
+		}
+		cmd := control.Decode(b)
+		for range run {
+			rec.Commands = append(rec.Commands, cmd)
+		}
+	}
+	return rec, nil
+}
+
+// noEOF reports a file ending too early as such rather than as io.EOF, which
+// readers take for a clean end.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
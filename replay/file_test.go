@@ -0,0 +1,85 @@
+package replay_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/replay"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+func TestWriteRead(t *testing.T) {
+	assert := assert.New(t)
+	rec, _ := record(simulation.ModeClassic, 600)
+
+	var buf bytes.Buffer
+	assert.NoError(replay.Write(&buf, rec))
+	got, err := replay.Read(bytes.NewReader(buf.Bytes()))
+	assert.NoError(err)
+	assert.Equal(rec, got)
+}
+
+func TestWriteRunLength(t *testing.T) {
+	assert := assert.New(t)
+	rec := &replay.Recording{Mode: simulation.ModeArcade, Config: config.Default()}
+	for range 10000 {
+		rec.Commands = append(rec.Commands, sprite.Command{Thrust: true})
+	}
+	rec.Commands = append(rec.Commands, sprite.Command{Fire: true})
+
+	var empty, buf bytes.Buffer
+	assert.NoError(replay.Write(&empty, &replay.Recording{Mode: rec.Mode, Config: rec.Config}))
+	assert.NoError(replay.Write(&buf, rec))
+	assert.Less(buf.Len()-empty.Len(), 10, "a run of commands takes a few bytes")
+
+	got, err := replay.Read(&buf)
+	assert.NoError(err)
+	assert.Equal(rec.Commands, got.Commands)
+}
+
+func TestReadErrors(t *testing.T) {
+	assert := assert.New(t)
+	rec, _ := record(simulation.ModeArcade, 120)
+	var buf bytes.Buffer
+	assert.NoError(replay.Write(&buf, rec))
+	data := buf.Bytes()
+
+	_, err := replay.Read(bytes.NewReader([]byte("PNG\x00 not a replay")))
+	assert.EqualError(err, "not a replay file")
+
+	newer := bytes.Clone(data)
+	newer[4] = 2
+	_, err = replay.Read(bytes.NewReader(newer))
+	assert.EqualError(err, "unsupported replay version 2")
+
+	_, err = replay.Read(bytes.NewReader(data[:len(data)-1]))
+	assert.ErrorIs(err, io.ErrUnexpectedEOF, "the commands are cut short")
+	_, err = replay.Read(bytes.NewReader(data[:20]))
+	assert.ErrorIs(err, io.ErrUnexpectedEOF, "the header is cut short")
+}
+
+func TestReadInvalidHeader(t *testing.T) {
+	assert := assert.New(t)
+	read := func(rec *replay.Recording) error {
+		var buf bytes.Buffer
+		assert.NoError(replay.Write(&buf, rec))
+		_, err := replay.Read(&buf)
+		return err
+	}
+
+	rec, _ := record(simulation.ModeArcade, 120)
+	rec.Mode = "turbo"
+	assert.ErrorContains(read(rec), "replay mode: ")
+
+	rec, _ = record(simulation.ModeArcade, 120)
+	bad := rec.Config
+	bad.Bullet.Speed = -1
+	rec.Changes = append(rec.Changes, replay.Change{Tick: 100, Config: bad})
+	assert.ErrorContains(read(rec), fmt.Sprintf("replay settings change %d at tick 100: bullet.speed must be positive", len(rec.Changes)-1))
+}
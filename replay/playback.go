@@ -0,0 +1,52 @@
+package replay
+
+import (
+	"asteroid/control"
+	"asteroid/simulation"
+)
+
+// Playback re-simulates a recording one tick at a time.
+type Playback struct {
+	World *simulation.World
+
+	rec  *Recording
+	ship *control.Replay
+	// change is the next change of the settings to apply.
+	change int
+}
+
+// NewPlayback creates the world of rec, before its first tick.
+func NewPlayback(rec *Recording) (*Playback, error) {
+	rules, err := simulation.RulesForMode(rec.Mode)
+	if err != nil {
+		return nil, err
+	}
+	rules.Waves = rec.schedule
+	return &Playback{
+		World: simulation.NewWorld(rec.Config, rec.Seed, rules),
+		rec:   rec,
+		ship:  control.NewReplay(rec.Commands),
+	}, nil
+}
+
+// Step plays the next recorded tick. It does nothing once Done.
+func (p *Playback) Step() {
+	if p.Done() {
+		return
+	}
+	tick := p.World.Clock.Ticks()
+	for ; p.change < len(p.rec.Changes) && p.rec.Changes[p.change].Tick <= tick; p.change++ {
+		p.World.ApplyConfig(p.rec.Changes[p.change].Config)
+	}
+	p.World.Step(p.ship.Command(p.World))
+}
+
+// Done reports whether every recorded tick has been played.
+func (p *Playback) Done() bool {
+	return p.ship.Done() || p.World.IsOver()
+}
+
+// Progress returns how many ticks have been played out of the recorded ones.
+func (p *Playback) Progress() (tick, ticks int) {
+	return int(p.World.Clock.Ticks()), len(p.rec.Commands)
+}
@@ -0,0 +1,65 @@
+package replay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/control"
+	"asteroid/replay"
+	"asteroid/simulation"
+)
+
+// record plays ticks of a game flown by the bot, reloading faster asteroid
+// settings halfway through.
+func record(mode string, ticks int) (*replay.Recording, *simulation.World) {
+	rules, _ := simulation.RulesForMode(mode)
+	rec, rules := replay.NewRecording(config.Default(), 9, rules)
+	w := simulation.NewWorld(config.Default(), 9, rules)
+	recorder := &control.Recorder{Controller: control.NewBot()}
+	for i := range ticks {
+		if i == ticks/2 {
+			cfg := config.Default()
+			cfg.Asteroid.MaxSpeed *= 2
+			w.ApplyConfig(cfg)
+			rec.ApplyConfig(w.Clock.Ticks(), cfg)
+		}
+		w.Step(recorder.Command(w))
+	}
+	rec.Commands = recorder.Commands
+	return rec, w
+}
+
+func TestPlayback(t *testing.T) {
+	for _, mode := range []string{simulation.ModeArcade, simulation.ModeClassic} {
+		t.Run(mode, func(t *testing.T) {
+			assert := assert.New(t)
+			rec, played := record(mode, 1800)
+
+			pb, err := replay.NewPlayback(rec)
+			assert.NoError(err)
+			for !pb.Done() {
+				pb.Step()
+			}
+			tick, ticks := pb.Progress()
+			assert.Equal(ticks, tick)
+			assert.Equal(played.Clock.Ticks(), pb.World.Clock.Ticks())
+			assert.Equal(played.Score, pb.World.Score)
+			assert.Equal(played.Wave, pb.World.Wave)
+			assert.Equal(played.Config, pb.World.Config)
+			assert.Equal(played.Player, pb.World.Player)
+			assert.Equal(played.AsteroidCtrl.Asteroids, pb.World.AsteroidCtrl.Asteroids)
+			assert.Equal(played.BulletCtrl.Bullets, pb.World.BulletCtrl.Bullets)
+
+			pb.Step()
+			assert.Equal(played.Clock.Ticks(), pb.World.Clock.Ticks(), "nothing is played past the recording")
+		})
+	}
+}
+
+func TestNewPlaybackUnknownMode(t *testing.T) {
+	assert := assert.New(t)
+	_, err := replay.NewPlayback(&replay.Recording{Mode: "golf", Config: config.Default()})
+	assert.Error(err)
+}
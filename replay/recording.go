@@ -0,0 +1,62 @@
+// Package replay records games and plays them back. A recording holds
+// everything a world is created with and the command of the ship on every
+// tick, so playing it back re-simulates the game exactly.
+package replay
+
+import (
+	"asteroid/config"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+// Recording is a recorded game.
+type Recording struct {
+	Seed   uint64
+	Mode   string
+	Config config.Config
+	// Waves are the waves the game reached, as its schedule returned them.
+	Waves []sprite.Wave
+	// Changes are the settings reloaded while the game was played.
+	Changes []Change
+	// Commands are the commands of the ship, one per tick.
+	Commands []sprite.Command
+}
+
+// Change is a reload of the settings, applied before tick Tick.
+type Change struct {
+	Tick   uint64
+	Config config.Config
+}
+
+// NewRecording starts recording a game of rules with the settings of cfg
+// seeded with seed. The returned rules are the ones to create the world with:
+// their wave schedule notes the waves the game reaches.
+func NewRecording(cfg config.Config, seed uint64, rules simulation.Rules) (*Recording, simulation.Rules) {
+	r := &Recording{Seed: seed, Mode: rules.Mode, Config: cfg}
+	schedule := rules.Waves
+	if schedule == nil {
+		schedule = simulation.DefaultWaves(cfg.Asteroid)
+	}
+	rules.Waves = func(n int) sprite.Wave {
+		for len(r.Waves) < n {
+			r.Waves = append(r.Waves, schedule(len(r.Waves)+1))
+		}
+		return r.Waves[max(n, 1)-1]
+	}
+	return r, rules
+}
+
+// ApplyConfig notes that the settings of the recorded game were changed to cfg
+// before tick.
+func (r *Recording) ApplyConfig(tick uint64, cfg config.Config) {
+	r.Changes = append(r.Changes, Change{Tick: tick, Config: cfg})
+}
+
+// schedule returns the recorded waves. A recording never plays past the
+// waves it reached, the last one is repeated just in case.
+func (r *Recording) schedule(n int) sprite.Wave {
+	if len(r.Waves) == 0 {
+		return sprite.Wave{}
+	}
+	return r.Waves[min(max(n, 1), len(r.Waves))-1]
+}
@@ -0,0 +1,43 @@
+package replay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/replay"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+func TestNewRecording(t *testing.T) {
+	assert := assert.New(t)
+	rules, _ := simulation.RulesForMode(simulation.ModeClassic)
+	calls := 0
+	rules.Waves = func(n int) sprite.Wave {
+		calls++
+		return sprite.Wave{Count: n}
+	}
+
+	rec, recorded := replay.NewRecording(config.Default(), 5, rules)
+	assert.Equal(uint64(5), rec.Seed)
+	assert.Equal(simulation.ModeClassic, rec.Mode)
+	assert.Equal(config.Default(), rec.Config)
+	assert.Empty(rec.Waves)
+
+	assert.Equal(sprite.Wave{Count: 2}, recorded.Waves(2))
+	assert.Equal(sprite.Wave{Count: 1}, recorded.Waves(1))
+	assert.Equal([]sprite.Wave{{Count: 1}, {Count: 2}}, rec.Waves)
+	assert.Equal(2, calls, "every wave is scheduled once")
+}
+
+func TestNewRecordingDefaultWaves(t *testing.T) {
+	assert := assert.New(t)
+	rules, _ := simulation.RulesForMode(simulation.ModeArcade)
+	cfg := config.Default()
+
+	rec, recorded := replay.NewRecording(cfg, 1, rules)
+	assert.Equal(simulation.DefaultWaves(cfg.Asteroid)(3), recorded.Waves(3))
+	assert.Len(rec.Waves, 3)
+}
@@ -0,0 +1,92 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"asteroid/storage"
+)
+
+const (
+	dirName = "replays"
+	ext     = ".replay"
+
+	// DefaultKeep is how many replays are kept by default.
+	DefaultKeep = 20
+)
+
+// DefaultDir returns the replay directory under the user's config directory.
+func DefaultDir() (string, error) {
+	return storage.Path(dirName)
+}
+
+// Store keeps the replays of the last Keep games in Dir, named after the time
+// they were saved at, so the game a tester is reporting is easy to find.
+type Store struct {
+	Dir  string
+	Keep int
+}
+
+func NewStore(dir string, keep int) *Store {
+	return &Store{Dir: dir, Keep: keep}
+}
+
+// Save writes rec as the replay of a game finished at now, removes the
+// oldest replays beyond Keep and returns the path of the new one.
+func (s *Store) Save(rec *Recording, now time.Time) (string, error) {
+	name := fmt.Sprintf("%s-%d%s", now.Format("20060102-150405"), rec.Seed, ext)
+	path := filepath.Join(s.Dir, name)
+	if err := Save(path, rec); err != nil {
+		return "", err
+	}
+	return path, s.prune()
+}
+
+// prune removes the oldest replays beyond Keep. Their names start with the
+// time they were saved at, so they sort oldest first.
+func (s *Store) prune() error {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ext) {
+			names = append(names, e.Name())
+		}
+	}
+	slices.Sort(names)
+	for len(names) > s.Keep {
+		if err := os.Remove(filepath.Join(s.Dir, names[0])); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// Load reads the recording at path.
+func Load(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rec, nil
+}
+
+// Save replaces the file at path with rec.
+func Save(path string, rec *Recording) error {
+	return storage.WriteFile(path, func(w io.Writer) error {
+		return Write(w, rec)
+	})
+}
@@ -0,0 +1,47 @@
+package replay_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/replay"
+	"asteroid/simulation"
+)
+
+func TestStore(t *testing.T) {
+	assert := assert.New(t)
+	dir := filepath.Join(t.TempDir(), "replays")
+	s := replay.NewStore(dir, 2)
+	rec, _ := record(simulation.ModeArcade, 60)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	var paths []string
+	for i := range 3 {
+		path, err := s.Save(rec, start.Add(time.Duration(i)*time.Minute))
+		assert.NoError(err)
+		paths = append(paths, path)
+	}
+	assert.Equal(filepath.Join(dir, "20240501-120000-9.replay"), paths[0])
+
+	_, err := os.Stat(paths[0])
+	assert.ErrorIs(err, os.ErrNotExist, "the oldest replay is removed")
+	entries, _ := os.ReadDir(dir)
+	assert.Len(entries, 2, "no temporary files are left behind")
+
+	got, err := replay.Load(paths[2])
+	assert.NoError(err)
+	assert.Equal(rec, got)
+}
+
+func TestLoadNotAReplay(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	assert.NoError(os.WriteFile(path, []byte("I died weirdly"), 0o644))
+
+	_, err := replay.Load(path)
+	assert.EqualError(err, path+": not a replay file")
+}
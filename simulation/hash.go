@@ -0,0 +1,243 @@
+package simulation
+
+import (
+	"asteroid/sprite"
+	"asteroid/utils"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Hash returns a hash of the whole state of the world, the same as the hash of
+// its snapshot. It reads the world in place, without copying or allocating, so
+// it can be taken every tick. Two worlds which play out the same way hash the
+// same on every tick, so the first tick their hashes differ is where they
+// diverged.
+func (w *World) Hash() uint64 {
+	h := newHasher()
+	h.string(w.Rules.Mode)
+	h.uint(w.Seed)
+	h.uint(w.Clock.Ticks())
+	// the settings are few and rarely change, reflection will do
+	h.value(reflect.ValueOf(&w.Config).Elem())
+	h.value(reflect.ValueOf(&w.base).Elem())
+	h.value(reflect.ValueOf(w.Scoring))
+	h.int(int64(w.Score))
+	h.int(int64(w.Lives))
+	h.int(int64(w.Wave))
+
+	h.bool(w.over)
+	h.bool(w.respawning)
+	h.int(int64(w.respawnAt))
+	h.bool(w.betweenWaves)
+	h.int(int64(w.nextWave))
+
+	p := w.Player.State()
+	h.vector(p.Center)
+	h.vector(p.Direction)
+	h.vector(p.Velocity)
+	h.bool(p.Destroyed)
+	h.int(int64(p.NextFire))
+	h.int(int64(p.InvulnerableUntil))
+
+	h.uint(uint64(len(w.AsteroidCtrl.Asteroids)))
+	for _, a := range w.AsteroidCtrl.Asteroids {
+		h.body(a.State())
+	}
+	s := w.AsteroidCtrl.SpawnState()
+	h.value(reflect.ValueOf(&s.Wave).Elem())
+	h.int(int64(s.ToSpawn))
+	h.int(int64(s.NextSpawn))
+	h.int(int64(s.WaveStart))
+	h.int(int64(s.NextEvent))
+	var buf [32]byte
+	rng := w.AsteroidCtrl.AppendRNG(buf[:0])
+	h.uint(uint64(len(rng)))
+	for _, b := range rng {
+		h.uint(uint64(b))
+	}
+
+	h.uint(uint64(len(w.BulletCtrl.Bullets)))
+	for _, b := range w.BulletCtrl.Bullets {
+		h.body(b.State())
+	}
+	return uint64(h)
+}
+
+// Hash returns a canonical hash of s: every field is hashed in declaration
+// order, entities in the order they are kept in, floats by their bits and the
+// random number generator by its state.
+func (s *Snapshot) Hash() uint64 {
+	h := newHasher()
+	h.value(reflect.ValueOf(s).Elem())
+	return uint64(h)
+}
+
+// hasher is an FNV-64a hash of the values written to it, each as 8 bytes in
+// little endian order.
+type hasher uint64
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+func newHasher() hasher {
+	return fnvOffset
+}
+
+func (h *hasher) byte(b byte) {
+	*h ^= hasher(b)
+	*h *= fnvPrime
+}
+
+func (h *hasher) uint(u uint64) {
+	x := *h
+	for range 8 {
+		x ^= hasher(u & 0xff)
+		x *= fnvPrime
+		u >>= 8
+	}
+	*h = x
+}
+
+func (h *hasher) int(i int64) {
+	h.uint(uint64(i))
+}
+
+func (h *hasher) float(f float64) {
+	h.uint(math.Float64bits(f))
+}
+
+func (h *hasher) bool(b bool) {
+	if b {
+		h.uint(1)
+	} else {
+		h.uint(0)
+	}
+}
+
+func (h *hasher) string(s string) {
+	h.uint(uint64(len(s)))
+	for i := range len(s) {
+		h.byte(s[i])
+	}
+}
+
+func (h *hasher) vector(v utils.Vector2) {
+	h.float(v.X)
+	h.float(v.Y)
+}
+
+func (h *hasher) body(s sprite.BodyState) {
+	h.vector(s.Center)
+	h.int(int64(s.Radius))
+	h.float(s.Speed)
+	h.vector(s.Direction)
+	h.bool(s.Destroyed)
+	h.float(s.Travelled)
+}
+
+// value hashes v field by field.
+func (h *hasher) value(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		h.bool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		h.uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.float(v.Float())
+	case reflect.String:
+		h.string(v.String())
+	case reflect.Slice, reflect.Array:
+		// the length keeps [a, b], [] apart from [a], [b]
+		h.uint(uint64(v.Len()))
+		for i := range v.Len() {
+			h.value(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			h.value(v.Field(i))
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			h.uint(0)
+			return
+		}
+		h.uint(1)
+		h.value(v.Elem())
+	default:
+		// maps would hash in a random order
+		panic(fmt.Sprintf("snapshot: can not hash a %s", v.Type()))
+	}
+}
+
+// Diff lists the differences between s and o, one per line, such as
+// "asteroids.asteroids[3].center.x: 10 != 10.5". It is empty when they are
+// the same.
+func (s *Snapshot) Diff(o *Snapshot) []string {
+	var diffs []string
+	diffValue(&diffs, "", reflect.ValueOf(s).Elem(), reflect.ValueOf(o).Elem())
+	return diffs
+}
+
+func diffValue(diffs *[]string, path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Struct:
+		t := a.Type()
+		for i := range a.NumField() {
+			diffValue(diffs, join(path, fieldName(t.Field(i))), a.Field(i), b.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if a.Type().Elem().Kind() == reflect.Uint8 {
+			// the state of the generator reads best as a whole
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				*diffs = append(*diffs, fmt.Sprintf("%s: %x != %x", path, a.Interface(), b.Interface()))
+			}
+			return
+		}
+		for i := range min(a.Len(), b.Len()) {
+			diffValue(diffs, fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+		if a.Len() != b.Len() {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %d entries != %d entries", path, a.Len(), b.Len()))
+		}
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, a.Interface(), b.Interface()))
+			}
+			return
+		}
+		diffValue(diffs, path, a.Elem(), b.Elem())
+	case reflect.Float32, reflect.Float64:
+		// by their bits, like Hash
+		if math.Float64bits(a.Float()) != math.Float64bits(b.Float()) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, a.Float(), b.Float()))
+		}
+	default:
+		if !a.Equal(b) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, a.Interface(), b.Interface()))
+		}
+	}
+}
+
+// fieldName returns the name of a field in its JSON encoding, which is how
+// snapshots are read by people.
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
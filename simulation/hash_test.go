@@ -0,0 +1,95 @@
+package simulation_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"asteroid/utils"
+)
+
+func TestWorldHashEveryTick(t *testing.T) {
+	assert := assert.New(t)
+	a := newTestWorldWithMode(simulation.ModeClassic)
+	b := newTestWorldWithMode(simulation.ModeClassic)
+	seen := map[uint64]bool{}
+
+	for i := range 1200 {
+		cmd := sprite.Command{RotateRight: i%100 < 30, Thrust: i%50 < 10, Fire: true}
+		a.Step(cmd)
+		b.Step(cmd)
+		if !assert.Equal(a.Hash(), b.Hash(), "tick %d", i+1) ||
+			!assert.Equal(a.Snapshot().Hash(), a.Hash(), "tick %d: a world hashes as its snapshot", i+1) {
+			return
+		}
+		seen[a.Hash()] = true
+	}
+	assert.Len(seen, 1200, "every tick hashes differently")
+}
+
+func TestSnapshotHash(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 100, Y: 100}, 20, 50, utils.Vector2{X: 1, Y: 0}))
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 300, Y: 100}, 40, 50, utils.Vector2{X: 0, Y: 1}))
+	base := w.Snapshot()
+	assert.Equal(base.Hash(), w.Snapshot().Hash())
+
+	changes := map[string]func(s *simulation.Snapshot){
+		"the last bit of a float": func(s *simulation.Snapshot) {
+			s.Player.Center.X = math.Nextafter(s.Player.Center.X, math.Inf(1))
+		},
+		"the order of the asteroids": func(s *simulation.Snapshot) {
+			a := s.Asteroids.Asteroids
+			a[0], a[1] = a[1], a[0]
+		},
+		"the generator": func(s *simulation.Snapshot) {
+			s.Asteroids.RNG[len(s.Asteroids.RNG)-1]++
+		},
+		"a flag": func(s *simulation.Snapshot) {
+			s.Respawning = true
+		},
+		"a setting": func(s *simulation.Snapshot) {
+			s.Config.Bullet.Speed++
+		},
+	}
+	for name, change := range changes {
+		s := w.Snapshot()
+		change(s)
+		assert.NotEqual(base.Hash(), s.Hash(), name)
+	}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 100, Y: 100}, 20, 50, utils.Vector2{X: 1, Y: 0}))
+	a := w.Snapshot()
+	assert.Empty(a.Diff(w.Snapshot()))
+
+	w.Score = 50
+	w.AsteroidCtrl.Asteroids[0].Center.X = 100.5
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 200, Y: 100}, 20, 50, utils.Vector2{X: 1, Y: 0}))
+	assert.Equal([]string{
+		"score: 0 != 50",
+		"asteroids.asteroids[0].center.x: 100 != 100.5",
+		"asteroids.asteroids: 1 entries != 2 entries",
+	}, a.Diff(w.Snapshot()))
+}
+
+func BenchmarkWorldHash(b *testing.B) {
+	for _, n := range []int{10, 1000, 50000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			w := crowdedWorld(n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				w.Hash()
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package simulation
+
+import (
+	"runtime"
+	"sync"
+)
+
+// phase is a step of a tick. The phases run one after the other on the
+// goroutine calling Step, each seeing everything the previous ones did, so
+// a tick always plays out the same way.
+type phase struct {
+	Name string
+	run  func(w *World)
+}
+
+var phases = []phase{
+	// the ship follows its command
+	{"input", (*World).steer},
+	// asteroids and bullets move and are wrapped or dropped at the edges
+	{"movement", (*World).move},
+	// the asteroids of the wave which are due enter the field
+	{"spawning", (*World).spawn},
+	// the ship comes back once its spawn point is clear, then asteroids hit
+	// the ship and bullets hit asteroids
+	{"collision", (*World).collide},
+	// destroyed entities are removed
+	{"cleanup", (*World).cleanup},
+	// waves end and start, and the gun fires a bullet which moves from the
+	// next tick on
+	{"events", (*World).events},
+}
+
+// Phases lists the names of the phases of a tick, in the order they run.
+func Phases() []string {
+	names := make([]string, len(phases))
+	for i, p := range phases {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func (w *World) steer() {
+	if w.respawning {
+		return
+	}
+	w.Player.Update(w.cmd)
+}
+
+func (w *World) move() {
+	w.Pool.forEach(len(w.AsteroidCtrl.Asteroids), w.AsteroidCtrl.Move)
+	w.Pool.forEach(len(w.BulletCtrl.Bullets), w.BulletCtrl.Move)
+}
+
+func (w *World) spawn() {
+	w.AsteroidCtrl.Spawn()
+}
+
+func (w *World) collide() {
+	if w.respawning {
+		w.tryRespawn()
+	}
+	if !w.respawning && !w.Player.IsInvulnerable() && w.IsPlayerCollidedWithAsteroid() {
+		w.loseLife()
+		if w.over {
+			return
+		}
+	}
+	w.CheckBulletCollidedWithAsteroid()
+}
+
+func (w *World) cleanup() {
+	w.BulletCtrl.Clean()
+	w.AsteroidCtrl.Clean()
+}
+
+func (w *World) events() {
+	w.updateWaves()
+	if w.cmd.Fire {
+		w.fire()
+	}
+}
+
+const (
+	// parallelMin is the smallest batch a Pool splits up. Below it handing the
+	// work to other goroutines costs more than doing it.
+	parallelMin = 4096
+	// chunkSize is the number of items of a batch done by a single task.
+	chunkSize = 1024
+)
+
+// Pool is a fixed set of goroutines working through large batches of
+// independent items, such as moving tens of thousands of asteroids. Batches
+// are split into chunks by their size alone, never by the number of workers,
+// and every item is only touched by the chunk it is in, so the result is the
+// same as doing the batch in order. A nil Pool does everything on the calling
+// goroutine.
+type Pool struct {
+	tasks chan func()
+}
+
+// NewPool starts workers goroutines, which run until Close. Less than one
+// worker starts one per CPU.
+func NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	p := &Pool{tasks: make(chan func())}
+	for range workers {
+		go func() {
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+// Close stops the workers once they are done.
+func (p *Pool) Close() {
+	close(p.tasks)
+}
+
+// forEach calls fn with the bounds of every chunk of [0, n) and returns once
+// all of them are done. Small batches are done on the calling goroutine.
+func (p *Pool) forEach(n int, fn func(lo, hi int)) {
+	if p == nil || n < parallelMin {
+		fn(0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunkSize {
+		hi := min(lo+chunkSize, n)
+		wg.Add(1)
+		p.tasks <- func() {
+			defer wg.Done()
+			fn(lo, hi)
+		}
+	}
+	wg.Wait()
+}
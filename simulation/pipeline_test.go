@@ -0,0 +1,84 @@
+package simulation_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"asteroid/utils"
+)
+
+// crowdedWorld returns a classic world with n asteroids drifting about and a
+// ship which cannot be hit, so it keeps going however crowded it gets.
+func crowdedWorld(n int) *simulation.World {
+	rules, _ := simulation.RulesForMode(simulation.ModeClassic)
+	cfg := config.Default()
+	w := simulation.NewWorld(cfg, 3, rules)
+	w.Player.MakeInvulnerable(time.Hour)
+
+	rng := rand.New(rand.NewPCG(3, 3))
+	for range n {
+		center := utils.Vector2{
+			X: rng.Float64() * float64(cfg.Screen.Width),
+			Y: rng.Float64() * float64(cfg.Screen.Height),
+		}
+		direction := utils.NewVector2(rng.Float64()*2-1, rng.Float64()*2-1)
+		w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(center, cfg.Asteroid.MinRadius, cfg.Asteroid.MinSpeed+rng.Float64()*cfg.Asteroid.MaxSpeed, *direction))
+	}
+	return w
+}
+
+func TestPhases(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]string{"input", "movement", "spawning", "collision", "cleanup", "events"}, simulation.Phases())
+}
+
+func TestWorldPoolPlaysTheSame(t *testing.T) {
+	// no workers picks one per CPU
+	for _, workers := range []int{4, 0} {
+		assert := assert.New(t)
+		pool := simulation.NewPool(workers)
+		defer pool.Close()
+
+		serial, parallel := crowdedWorld(10000), crowdedWorld(10000)
+		parallel.Pool = pool
+		cmd := sprite.Command{RotateLeft: true, Fire: true}
+		for range 60 {
+			serial.Step(cmd)
+			parallel.Step(cmd)
+			if !assert.Equal(serial.Hash(), parallel.Hash(), "%d workers, tick %d", workers, serial.Clock.Ticks()) {
+				assert.Empty(parallel.Snapshot().Diff(serial.Snapshot()))
+				return
+			}
+		}
+	}
+}
+
+func BenchmarkWorldStep(b *testing.B) {
+	for _, n := range []int{10, 1000, 50000} {
+		b.Run(fmt.Sprintf("%d/serial", n), func(b *testing.B) {
+			benchmarkStep(b, crowdedWorld(n))
+		})
+		b.Run(fmt.Sprintf("%d/pool", n), func(b *testing.B) {
+			pool := simulation.NewPool(4)
+			defer pool.Close()
+			w := crowdedWorld(n)
+			w.Pool = pool
+			benchmarkStep(b, w)
+		})
+	}
+}
+
+func benchmarkStep(b *testing.B, w *simulation.World) {
+	cmd := sprite.Command{RotateLeft: true}
+	b.ResetTimer()
+	for range b.N {
+		w.Step(cmd)
+	}
+}
@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"asteroid/sprite"
+	"fmt"
+)
+
+const (
+	ModeArcade  = "arcade"
+	ModeClassic = "classic"
+)
+
+// Rules are the gameplay variations a World can be played with.
+type Rules struct {
+	Mode             string
+	PlayerBoundary   sprite.BoundaryPolicy
+	AsteroidBoundary sprite.BoundaryPolicy
+	BulletBoundary   sprite.BoundaryPolicy
+	// LimitBulletRange makes bullets vanish once they have flown the wrap
+	// range of the bullet settings.
+	LimitBulletRange bool
+	Flight           sprite.FlightModel
+	// Waves is the wave schedule of the game, DefaultWaves when nil.
+	Waves WaveSchedule
+}
+
+// RulesForMode returns the preset rules of a game mode.
+func RulesForMode(mode string) (Rules, error) {
+	switch mode {
+	case ModeArcade:
+		return Rules{
+			Mode:             ModeArcade,
+			PlayerBoundary:   sprite.BoundaryClamp,
+			AsteroidBoundary: sprite.BoundaryDestroy,
+			BulletBoundary:   sprite.BoundaryDestroy,
+			Flight:           sprite.FlightArcade,
+		}, nil
+	case ModeClassic:
+		return Rules{
+			Mode:             ModeClassic,
+			PlayerBoundary:   sprite.BoundaryWrap,
+			AsteroidBoundary: sprite.BoundaryWrap,
+			BulletBoundary:   sprite.BoundaryWrap,
+			LimitBulletRange: true,
+			Flight:           sprite.FlightInertial,
+		}, nil
+	}
+	return Rules{}, fmt.Errorf("unknown game mode %q", mode)
+}
+
+// wraps reports whether any entity wraps around the bounds, so collisions
+// must also be tested across the edges.
+func (r Rules) wraps() bool {
+	return r.PlayerBoundary == sprite.BoundaryWrap ||
+		r.AsteroidBoundary == sprite.BoundaryWrap ||
+		r.BulletBoundary == sprite.BoundaryWrap
+}
@@ -0,0 +1,35 @@
+package simulation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+func TestRulesForMode(t *testing.T) {
+	assert := assert.New(t)
+
+	arcade, err := simulation.RulesForMode(simulation.ModeArcade)
+	assert.NoError(err)
+	assert.Equal(simulation.ModeArcade, arcade.Mode)
+	assert.Equal(sprite.BoundaryClamp, arcade.PlayerBoundary)
+	assert.Equal(sprite.BoundaryDestroy, arcade.AsteroidBoundary)
+	assert.Equal(sprite.BoundaryDestroy, arcade.BulletBoundary)
+	assert.False(arcade.LimitBulletRange)
+	assert.Equal(sprite.FlightArcade, arcade.Flight)
+
+	classic, err := simulation.RulesForMode(simulation.ModeClassic)
+	assert.NoError(err)
+	assert.Equal(simulation.ModeClassic, classic.Mode)
+	assert.Equal(sprite.BoundaryWrap, classic.PlayerBoundary)
+	assert.Equal(sprite.BoundaryWrap, classic.AsteroidBoundary)
+	assert.Equal(sprite.BoundaryWrap, classic.BulletBoundary)
+	assert.True(classic.LimitBulletRange)
+	assert.Equal(sprite.FlightInertial, classic.Flight)
+
+	_, err = simulation.RulesForMode("nope")
+	assert.Error(err)
+}
@@ -0,0 +1,20 @@
+package simulation
+
+// ScoreTable holds the points awarded for destroying an asteroid, indexed by its
+// size tier from the smallest asteroids up. Tiers past the end of the table
+// are worth its last entry.
+type ScoreTable []int
+
+// DefaultScoreTable rewards the small asteroids the most, as they are the
+// hardest to hit.
+func DefaultScoreTable() ScoreTable {
+	return ScoreTable{100, 50, 20}
+}
+
+// Points returns the points awarded for an asteroid of the given size tier.
+func (t ScoreTable) Points(tier int) int {
+	if len(t) == 0 {
+		return 0
+	}
+	return t[min(max(tier, 0), len(t)-1)]
+}
@@ -0,0 +1,21 @@
+package simulation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/simulation"
+)
+
+func TestScoreTablePoints(t *testing.T) {
+	assert := assert.New(t)
+	table := simulation.ScoreTable{100, 50, 20}
+
+	assert.Equal(100, table.Points(0))
+	assert.Equal(50, table.Points(1))
+	assert.Equal(20, table.Points(2))
+	assert.Equal(20, table.Points(5), "tiers past the table are worth the last entry")
+	assert.Equal(100, table.Points(-1))
+	assert.Equal(0, simulation.ScoreTable{}.Points(1))
+}
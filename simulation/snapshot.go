@@ -0,0 +1,96 @@
+package simulation
+
+import (
+	"asteroid/config"
+	"asteroid/sprite"
+	"time"
+)
+
+// Snapshot is the whole state of a world between two ticks. A world restored
+// from it plays on exactly as the world it was taken of, given the same
+// commands.
+type Snapshot struct {
+	Mode string `json:"mode"`
+	Seed uint64 `json:"seed"`
+	Tick uint64 `json:"tick"`
+	// Config are the settings the world is played with and Base the asteroid
+	// settings it was created with, which its waves are scaled by.
+	Config  config.Config   `json:"config"`
+	Base    config.Asteroid `json:"base"`
+	Scoring ScoreTable      `json:"scoring"`
+	Score   int             `json:"score"`
+	Lives   int             `json:"lives"`
+	Wave    int             `json:"wave"`
+
+	Over         bool          `json:"over,omitempty"`
+	Respawning   bool          `json:"respawning,omitempty"`
+	RespawnAt    time.Duration `json:"respawn_at"`
+	BetweenWaves bool          `json:"between_waves,omitempty"`
+	NextWave     time.Duration `json:"next_wave"`
+
+	Player    sprite.PlayerState          `json:"player"`
+	Asteroids sprite.AsteroidControlState `json:"asteroids"`
+	Bullets   sprite.BulletControlState   `json:"bullets"`
+}
+
+// Snapshot returns the state of the world.
+func (w *World) Snapshot() *Snapshot {
+	return &Snapshot{
+		Mode:         w.Rules.Mode,
+		Seed:         w.Seed,
+		Tick:         w.Clock.Ticks(),
+		Config:       w.Config,
+		Base:         w.base,
+		Scoring:      w.Scoring,
+		Score:        w.Score,
+		Lives:        w.Lives,
+		Wave:         w.Wave,
+		Over:         w.over,
+		Respawning:   w.respawning,
+		RespawnAt:    w.respawnAt,
+		BetweenWaves: w.betweenWaves,
+		NextWave:     w.nextWave,
+		Player:       w.Player.State(),
+		Asteroids:    w.AsteroidCtrl.State(),
+		Bullets:      w.BulletCtrl.State(),
+	}
+}
+
+// Restore creates the world s was taken of. The waves still to come are
+// scheduled by waves, DefaultWaves when nil, which should be the schedule
+// the world was played with.
+func Restore(s *Snapshot, waves WaveSchedule) (*World, error) {
+	rules, err := RulesForMode(s.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Config.Validate(); err != nil {
+		return nil, err
+	}
+	rules.Waves = waves
+
+	created := s.Config
+	created.Asteroid = s.Base
+	if rules.Waves == nil {
+		rules.Waves = DefaultWaves(s.Base)
+	}
+	w := NewWorld(created, s.Seed, rules)
+	w.ApplyConfig(s.Config)
+
+	w.Clock.Set(s.Tick)
+	w.Scoring = s.Scoring
+	w.Score = s.Score
+	w.Lives = s.Lives
+	w.Wave = s.Wave
+	w.over = s.Over
+	w.respawning = s.Respawning
+	w.respawnAt = s.RespawnAt
+	w.betweenWaves = s.BetweenWaves
+	w.nextWave = s.NextWave
+	w.Player.Restore(s.Player)
+	if err := w.AsteroidCtrl.Restore(s.Asteroids); err != nil {
+		return nil, err
+	}
+	w.BulletCtrl.Restore(s.Bullets)
+	return w, nil
+}
@@ -0,0 +1,106 @@
+package simulation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"asteroid/storage"
+)
+
+// Snapshots are written either as JSON, to be read and edited by hand when
+// setting up a scenario, or in a compact binary encoding: magic and the
+// version of the layout followed by the snapshot encoded with gob.
+const (
+	snapshotMagic = "ASTS"
+
+	// snapshotVersion is bumped whenever the layout of Snapshot changes.
+	snapshotVersion = 1
+)
+
+// snapshotFile is the layout of a JSON snapshot.
+type snapshotFile struct {
+	Version  int       `json:"version"`
+	Snapshot *Snapshot `json:"snapshot"`
+}
+
+// DefaultSaveDir returns the directory of the saved games under the user's
+// config directory.
+func DefaultSaveDir() (string, error) {
+	return storage.Path("saves")
+}
+
+// WriteJSON writes s to w as indented JSON.
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshotFile{Version: snapshotVersion, Snapshot: s})
+}
+
+// WriteBinary writes s to w in the binary encoding.
+func (s *Snapshot) WriteBinary(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(snapshotMagic)
+	bw.WriteByte(snapshotVersion)
+	if err := gob.NewEncoder(bw).Encode(s); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ReadSnapshot reads a snapshot in either encoding.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	br := bufio.NewReader(r)
+	if head, _ := br.Peek(len(snapshotMagic) + 1); bytes.HasPrefix(head, []byte(snapshotMagic)) {
+		if v := head[len(snapshotMagic)]; v != snapshotVersion {
+			return nil, fmt.Errorf("unsupported snapshot version %d", v)
+		}
+		br.Discard(len(head))
+		s := &Snapshot{}
+		if err := gob.NewDecoder(br).Decode(s); err != nil {
+			return nil, fmt.Errorf("read snapshot: %w", err)
+		}
+		return s, nil
+	}
+
+	var f snapshotFile
+	if err := json.NewDecoder(br).Decode(&f); err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	if f.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", f.Version)
+	}
+	if f.Snapshot == nil {
+		return nil, fmt.Errorf("read snapshot: missing snapshot")
+	}
+	return f.Snapshot, nil
+}
+
+// LoadSnapshot reads the snapshot at path, in either encoding.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ReadSnapshot(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SaveSnapshot replaces the file at path with s, as JSON if path ends in .json
+// and in the binary encoding otherwise.
+func SaveSnapshot(path string, s *Snapshot) error {
+	write := s.WriteBinary
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		write = s.WriteJSON
+	}
+	return storage.WriteFile(path, write)
+}
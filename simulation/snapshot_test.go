@@ -0,0 +1,129 @@
+package simulation_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/simulation"
+	"asteroid/sprite"
+)
+
+// playTicks steps w n times, turning and firing to keep things happening.
+func playTicks(w *simulation.World, n int) {
+	for i := range n {
+		w.Step(sprite.Command{RotateLeft: i%120 < 60, RotateRight: i%120 >= 60, Thrust: i%90 < 20, Fire: true})
+	}
+}
+
+// assertSamePlay steps both worlds and asserts they stay identical.
+func assertSamePlay(t *testing.T, want, got *simulation.World) {
+	assert := assert.New(t)
+	playTicks(want, 600)
+	playTicks(got, 600)
+	assert.Equal(want.Clock.Ticks(), got.Clock.Ticks())
+	assert.Equal(want.Score, got.Score)
+	assert.Equal(want.Lives, got.Lives)
+	assert.Equal(want.Wave, got.Wave)
+	assert.Equal(want.Player, got.Player)
+	assert.Equal(want.Snapshot(), got.Snapshot())
+}
+
+func TestWorldRestore(t *testing.T) {
+	for _, mode := range []string{simulation.ModeArcade, simulation.ModeClassic} {
+		t.Run(mode, func(t *testing.T) {
+			assert := assert.New(t)
+			w := newTestWorldWithMode(mode)
+			playTicks(w, 900)
+			cfg := config.Default()
+			cfg.Asteroid.MaxSpeed = 150
+			w.ApplyConfig(cfg)
+			playTicks(w, 100)
+
+			s := w.Snapshot()
+			assert.Equal(uint64(1000), s.Tick)
+			restored, err := simulation.Restore(s, nil)
+			assert.NoError(err)
+			assert.Equal(s, restored.Snapshot())
+			assertSamePlay(t, w, restored)
+		})
+	}
+}
+
+func TestWorldRestoreErrors(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestWorld().Snapshot()
+	s.Mode = "golf"
+	_, err := simulation.Restore(s, nil)
+	assert.EqualError(err, `unknown game mode "golf"`)
+
+	s = newTestWorld().Snapshot()
+	s.Config.Player.Lives = 0
+	_, err = simulation.Restore(s, nil)
+	assert.Error(err)
+}
+
+func TestSnapshotEncodings(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorldWithMode(simulation.ModeClassic)
+	playTicks(w, 1200)
+	s := w.Snapshot()
+
+	var js, bin bytes.Buffer
+	assert.NoError(s.WriteJSON(&js))
+	assert.NoError(s.WriteBinary(&bin))
+	assert.True(strings.HasPrefix(js.String(), "{\n  \"version\": 1,"))
+	assert.Less(bin.Len(), js.Len(), "the binary encoding is the compact one")
+
+	for name, data := range map[string][]byte{"json": js.Bytes(), "binary": bin.Bytes()} {
+		read, err := simulation.ReadSnapshot(bytes.NewReader(data))
+		if !assert.NoError(err, name) {
+			continue
+		}
+		restored, err := simulation.Restore(read, nil)
+		assert.NoError(err, name)
+		want, _ := simulation.Restore(s, nil)
+		assertSamePlay(t, want, restored)
+	}
+}
+
+func TestReadSnapshotErrors(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestWorld().Snapshot()
+	var bin bytes.Buffer
+	assert.NoError(s.WriteBinary(&bin))
+
+	data := bin.Bytes()
+	data[4] = 9
+	_, err := simulation.ReadSnapshot(bytes.NewReader(data))
+	assert.EqualError(err, "unsupported snapshot version 9")
+
+	_, err = simulation.ReadSnapshot(strings.NewReader(`{"version": 2, "snapshot": {}}`))
+	assert.EqualError(err, "unsupported snapshot version 2")
+	_, err = simulation.ReadSnapshot(strings.NewReader(`{"version": 1}`))
+	assert.Error(err)
+	_, err = simulation.ReadSnapshot(strings.NewReader("nonsense"))
+	assert.Error(err)
+}
+
+func TestSaveLoadSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	playTicks(w, 300)
+	s := w.Snapshot()
+
+	for _, name := range []string{"scenario.json", "slot1.snapshot"} {
+		path := filepath.Join(t.TempDir(), "saves", name)
+		assert.NoError(simulation.SaveSnapshot(path, s))
+		loaded, err := simulation.LoadSnapshot(path)
+		assert.NoError(err, name)
+		restored, err := simulation.Restore(loaded, nil)
+		assert.NoError(err, name)
+		assert.Equal(w.Player, restored.Player, name)
+		assert.Equal(w.Score, restored.Score, name)
+	}
+}
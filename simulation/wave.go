@@ -0,0 +1,61 @@
+package simulation
+
+import (
+	"asteroid/config"
+	"asteroid/sprite"
+	"math"
+	"slices"
+	"time"
+)
+
+// WaveSchedule returns the wave played as wave n, counting from 1. It is
+// where the difficulty of a game is tuned.
+type WaveSchedule func(n int) sprite.Wave
+
+// wave returns wave n of the schedule of the world, its speeds and spawn rate
+// scaled by how far the asteroid settings have been changed from the ones the
+// world was created with.
+func (w *World) wave(n int) sprite.Wave {
+	wave := w.Waves(n)
+	now := w.Config.Asteroid
+	if now == w.base {
+		return wave
+	}
+
+	minScale := scale(now.MinSpeed, w.base.MinSpeed)
+	maxScale := scale(now.MaxSpeed, w.base.MaxSpeed)
+	wave.MinSpeed *= minScale
+	wave.MaxSpeed *= maxScale
+	wave.SpawnRate = time.Duration(float64(wave.SpawnRate) * scale(float64(now.SpawnRate), float64(w.base.SpawnRate)))
+	wave.Events = slices.Clone(wave.Events)
+	for i := range wave.Events {
+		wave.Events[i].MinSpeed *= minScale
+		wave.Events[i].MaxSpeed *= maxScale
+	}
+	return wave
+}
+
+// scale returns how many times larger v is than base.
+func scale(v, base float64) float64 {
+	if base == 0 {
+		return 1
+	}
+	return v / base
+}
+
+// DefaultWaves starts with a few asteroids at the speeds and spawn rate of
+// a. Every following wave sends more of them, bigger on average, faster and at
+// a higher rate.
+func DefaultWaves(a config.Asteroid) WaveSchedule {
+	return func(n int) sprite.Wave {
+		step := float64(n - 1)
+		rate := a.SpawnRate
+		return sprite.Wave{
+			Count:     3 + 2*n,
+			Sizes:     []int{2, 2, n},
+			MinSpeed:  min(a.MinSpeed+5*step, a.MaxSpeed),
+			MaxSpeed:  min(a.MaxSpeed+10*step, 2*a.MaxSpeed),
+			SpawnRate: max(time.Duration(float64(rate)*math.Pow(0.9, step)), rate/4),
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package simulation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/simulation"
+)
+
+func TestDefaultWavesGrow(t *testing.T) {
+	assert := assert.New(t)
+	waves := simulation.DefaultWaves(config.Default().Asteroid)
+	prev := waves(1)
+	assert.Positive(prev.Count)
+	assert.LessOrEqual(prev.MinSpeed, prev.MaxSpeed)
+
+	for n := 2; n <= 30; n++ {
+		w := waves(n)
+		assert.Greater(w.Count, prev.Count)
+		assert.GreaterOrEqual(w.MinSpeed, prev.MinSpeed)
+		assert.GreaterOrEqual(w.MaxSpeed, prev.MaxSpeed)
+		assert.LessOrEqual(w.MinSpeed, w.MaxSpeed)
+		assert.LessOrEqual(w.SpawnRate, prev.SpawnRate)
+		assert.Positive(w.SpawnRate)
+		prev = w
+	}
+}
@@ -0,0 +1,323 @@
+package simulation
+
+import (
+	"asteroid/config"
+	"asteroid/physics"
+	"asteroid/sprite"
+	"asteroid/utils"
+
+	"image"
+	"log"
+	"time"
+)
+
+// World is the headless game simulation. It owns every entity of a single game
+// and advances them one fixed tick at a time, without depending on ebiten at
+// all: the render package draws it.
+type World struct {
+	Player       sprite.Player
+	AsteroidCtrl sprite.AsteroidControl
+	BulletCtrl   sprite.BulletControl
+	Bounds       image.Rectangle
+	Config       config.Config
+	Seed         uint64
+	Clock        *sprite.TickClock
+	Rules        Rules
+	Scoring      ScoreTable
+	Score        int
+	Lives        int
+	Waves        WaveSchedule
+	// Wave is the number of the current wave, 0 before the first one.
+	Wave int
+	// Pool moves large numbers of entities in parallel, when set. Worlds
+	// play out the same with or without it.
+	Pool *Pool
+
+	cmd        sprite.Command
+	over       bool
+	spawnPoint utils.Vector2
+	// respawning is set while the ship is waiting to come back after losing a life.
+	respawning bool
+	respawnAt  time.Duration
+	// betweenWaves is set from clearing a wave until nextWave starts.
+	betweenWaves bool
+	nextWave     time.Duration
+	// base are the asteroid settings the world was created with, which the
+	// wave schedule is tuned for.
+	base       config.Asteroid
+	hash       *physics.SpatialHash
+	candidates []int
+	offsets    []utils.Vector2
+}
+
+// NewWorld creates a world with the settings of cfg, filling a screen of its
+// size, played with rules. Every random decision of the world is derived from
+// seed, so two worlds with the same settings, seed, rules and inputs play out
+// identically.
+func NewWorld(cfg config.Config, seed uint64, rules Rules) *World {
+	bounds := image.Rect(0, 0, cfg.Screen.Width, cfg.Screen.Height)
+	center := utils.Vector2{
+		X: float64(bounds.Min.X+bounds.Max.X) / 2,
+		Y: float64(bounds.Min.Y+bounds.Max.Y) / 2,
+	}
+	gun := sprite.GunConfig{
+		Radius:    cfg.Bullet.Radius,
+		Speed:     cfg.Bullet.Speed,
+		RateLimit: cfg.Player.FireRate,
+	}
+	flight := sprite.FlightConfig{
+		Model:  rules.Flight,
+		Thrust: cfg.Player.Thrust,
+		Drag:   cfg.Player.Drag,
+	}
+	clock := sprite.NewTickClock()
+	player := sprite.NewPlayer(center, cfg.Player.Radius, bounds, cfg.Player.MoveSpeed, cfg.Player.RotationSpeed, gun, flight, clock)
+	asteroidCtrl := sprite.NewAsteroidControl(
+		cfg.Asteroid.MinRadius,
+		cfg.Asteroid.Kinds,
+		bounds,
+		cfg.Asteroid.SpawnRate,
+		cfg.Asteroid.MaxSpeed,
+		cfg.Asteroid.MinSpeed,
+		cfg.Asteroid.MaxAngle,
+		seed,
+		clock,
+	)
+
+	player.Boundary = rules.PlayerBoundary
+	asteroidCtrl.Boundary = rules.AsteroidBoundary
+	bulletCtrl := sprite.NewBulletControl(bounds)
+	bulletCtrl.Boundary = rules.BulletBoundary
+	if rules.LimitBulletRange {
+		bulletCtrl.Range = cfg.Bullet.WrapRange
+	}
+
+	if rules.Waves == nil {
+		rules.Waves = DefaultWaves(cfg.Asteroid)
+	}
+
+	return &World{
+		Player:       *player,
+		AsteroidCtrl: *asteroidCtrl,
+		BulletCtrl:   *bulletCtrl,
+		Bounds:       bounds,
+		Config:       cfg,
+		Seed:         seed,
+		Clock:        clock,
+		Rules:        rules,
+		Scoring:      DefaultScoreTable(),
+		Lives:        cfg.Player.Lives,
+		Waves:        rules.Waves,
+		betweenWaves: true,
+		nextWave:     cfg.Wave.Intermission,
+		base:         cfg.Asteroid,
+		spawnPoint:   center,
+		// the largest asteroid fits in a single cell
+		hash: physics.NewSpatialHash(float64(2 * cfg.Asteroid.MaxRadius())),
+	}
+}
+
+// ApplyConfig changes the speeds, the fire rate, the spawn rate and the guns
+// of the world to those of cfg from the next tick on. Asteroids already on the
+// field keep their speed. The sizes of the screen and of the entities and the
+// number of lives only apply to new worlds.
+func (w *World) ApplyConfig(cfg config.Config) {
+	cfg.Screen = w.Config.Screen
+	cfg.Player.Radius = w.Config.Player.Radius
+	cfg.Player.Lives = w.Config.Player.Lives
+	cfg.Asteroid.MinRadius = w.Config.Asteroid.MinRadius
+	cfg.Asteroid.Kinds = w.Config.Asteroid.Kinds
+	w.Config = cfg
+
+	w.Player.Speed = cfg.Player.MoveSpeed
+	w.Player.RotationSpeed = cfg.Player.RotationSpeed
+	w.Player.Flight.Thrust = cfg.Player.Thrust
+	w.Player.Flight.Drag = cfg.Player.Drag
+	w.Player.Gun = sprite.GunConfig{
+		Radius:    cfg.Bullet.Radius,
+		Speed:     cfg.Bullet.Speed,
+		RateLimit: cfg.Player.FireRate,
+	}
+	if w.Rules.LimitBulletRange {
+		w.BulletCtrl.Range = cfg.Bullet.WrapRange
+	}
+
+	factory := w.AsteroidCtrl.AsteroidFactory
+	factory.MinSpeed = cfg.Asteroid.MinSpeed
+	factory.MaxSpeed = cfg.Asteroid.MaxSpeed
+	factory.MaxAngle = cfg.Asteroid.MaxAngle
+	if w.Wave > 0 {
+		// the rest of the current wave spawns with the new settings
+		w.AsteroidCtrl.Wave = w.wave(w.Wave)
+	}
+}
+
+// Step advances the world by a single tick, flying the ship with cmd. It runs
+// every phase of the tick in order and does nothing once the world is over.
+func (w *World) Step(cmd sprite.Command) {
+	if w.over {
+		return
+	}
+	w.cmd = cmd
+	w.Clock.Tick()
+
+	for _, p := range phases {
+		p.run(w)
+		if w.over {
+			return
+		}
+	}
+}
+
+// IsOver reports whether the player has run out of lives.
+func (w *World) IsOver() bool {
+	return w.over
+}
+
+// IsRespawning reports whether the ship is off the field after losing a life.
+func (w *World) IsRespawning() bool {
+	return w.respawning
+}
+
+// UpcomingWave returns the number of the next wave while the world is waiting
+// for it to start. It returns false while a wave is being played.
+func (w *World) UpcomingWave() (int, bool) {
+	return w.Wave + 1, w.betweenWaves
+}
+
+// updateWaves starts the next wave once the current one has been cleared and
+// the intermission is over.
+func (w *World) updateWaves() {
+	now := w.Clock.Now()
+	if !w.betweenWaves {
+		if w.AsteroidCtrl.IsWaveCleared() {
+			w.betweenWaves = true
+			w.nextWave = now + w.Config.Wave.Intermission
+		}
+		return
+	}
+	if now >= w.nextWave {
+		w.Wave++
+		w.betweenWaves = false
+		w.AsteroidCtrl.StartWave(w.wave(w.Wave))
+	}
+}
+
+// loseLife takes a life from the player and either ends the world or starts
+// the respawn sequence.
+func (w *World) loseLife() {
+	w.Lives--
+	if w.Lives <= 0 {
+		w.over = true
+		return
+	}
+	w.respawning = true
+	w.respawnAt = w.Clock.Now() + w.Config.Player.RespawnDelay
+}
+
+// tryRespawn brings the ship back at the spawn point once the respawn delay
+// has passed and no asteroid is within the clearance of the spawn point.
+func (w *World) tryRespawn() {
+	if w.Clock.Now() < w.respawnAt {
+		return
+	}
+	for _, a := range w.AsteroidCtrl.Asteroids {
+		if a.IsDestoryed() {
+			continue
+		}
+		d := utils.Distance(w.spawnPoint.X, w.spawnPoint.Y, a.Center.X, a.Center.Y)
+		if d < w.Config.Player.RespawnClearance+float64(a.Radius) {
+			return
+		}
+	}
+	w.Player.Respawn(w.spawnPoint, w.Config.Player.InvulnerableTime)
+	w.respawning = false
+}
+
+// fire shoots a bullet from the ship if its gun is ready.
+func (w *World) fire() {
+	if w.respawning {
+		return
+	}
+	bullet, err := w.Player.Fire()
+	if err != nil {
+		if err == sprite.ErrGunNotReady {
+			return
+		}
+		log.Fatal(err)
+	}
+	w.BulletCtrl.AddBullet(bullet)
+}
+
+// buildBroadPhase rebuilds the spatial hash of the asteroids from their
+// current positions.
+func (w *World) buildBroadPhase() {
+	w.hash.Clear()
+	w.insertAsteroids(0)
+}
+
+// insertAsteroids adds the asteroids from index start onwards to the spatial hash.
+func (w *World) insertAsteroids(start int) {
+	for i := start; i < len(w.AsteroidCtrl.Asteroids); i++ {
+		a := w.AsteroidCtrl.Asteroids[i]
+		c, r := physics.SweptBounds(a.PrevCenter(), a.Center, float64(a.Radius))
+		w.hash.Insert(i, c, r)
+	}
+}
+
+// seamOffsets returns the translations at which an entity at center with
+// radius is tested against the asteroids. When the world wraps, these include
+// its images across the edges, so collisions happen across the seam too.
+func (w *World) seamOffsets(center utils.Vector2, radius float64) []utils.Vector2 {
+	w.offsets = w.offsets[:0]
+	if !w.Rules.wraps() {
+		return append(w.offsets, utils.Vector2{})
+	}
+	return physics.WrapOffsets(center, radius+float64(w.Config.Asteroid.MaxRadius()), w.Bounds, w.offsets)
+}
+
+func (w *World) IsPlayerCollidedWithAsteroid() bool {
+	w.buildBroadPhase()
+	p, r := w.Player.Hitbox().BoundingCircle()
+	for _, o := range w.seamOffsets(p, r) {
+		ghost := w.Player
+		ghost.Center.Add(o)
+		w.candidates = w.hash.Query(*p.Clone().Add(o), r, w.candidates[:0])
+		for _, j := range w.candidates {
+			a := w.AsteroidCtrl.Asteroids[j]
+			if ghost.IsCollided(a) {
+				log.Printf("Player(%.2f, %.2f) collided with Asteroid(%.2f, %.2f)", w.Player.Center.X, w.Player.Center.Y, a.Center.X, a.Center.Y)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *World) CheckBulletCollidedWithAsteroid() {
+	w.buildBroadPhase()
+	for i, b := range w.BulletCtrl.Bullets {
+		p, r := physics.SweptBounds(b.PrevCenter(), b.Center, float64(b.Radius))
+		for _, o := range w.seamOffsets(p, r) {
+			ghost := b.Circle
+			ghost.Center.Add(o)
+			w.candidates = w.hash.Query(*p.Clone().Add(o), r, w.candidates[:0])
+			for _, j := range w.candidates {
+				a := w.AsteroidCtrl.Asteroids[j]
+				if b.IsDestoryed() || a.IsDestoryed() {
+					continue
+				}
+
+				if ghost.IsSweptCollided(&a.Circle) {
+					log.Printf("Bullet(%.2f, %.2f) collided with Asteroid(%.2f, %.2f)", b.Center.X, b.Center.Y, a.Center.X, a.Center.Y)
+					w.BulletCtrl.HitBullet(i)
+					w.Score += w.Scoring.Points(w.AsteroidCtrl.Tier(a))
+					n := len(w.AsteroidCtrl.Asteroids)
+					w.AsteroidCtrl.HitAsteroid(j)
+					// fragments can be hit by the remaining bullets of this tick
+					w.insertAsteroids(n)
+				}
+			}
+		}
+	}
+}
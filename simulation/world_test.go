@@ -0,0 +1,305 @@
+package simulation_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/config"
+	"asteroid/simulation"
+	"asteroid/sprite"
+	"asteroid/utils"
+)
+
+func newTestWorld() *simulation.World {
+	return newTestWorldWithMode(simulation.ModeArcade)
+}
+
+func newTestWorldWithMode(mode string) *simulation.World {
+	rules, err := simulation.RulesForMode(mode)
+	if err != nil {
+		panic(err)
+	}
+	return simulation.NewWorld(config.Default(), 1, rules)
+}
+
+func TestNewWorld(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+
+	assert.Equal(utils.Vector2{X: 640, Y: 360}, w.Player.Center)
+	assert.Equal(image.Rect(0, 0, 1280, 720), w.Bounds)
+	assert.Equal(0, len(w.AsteroidCtrl.Asteroids))
+	assert.Equal(0, len(w.BulletCtrl.Bullets))
+	assert.False(w.IsOver())
+	assert.Equal(0, w.Score)
+	assert.Equal(simulation.DefaultScoreTable(), w.Scoring)
+	assert.Equal(3, w.Lives)
+}
+
+func TestNewWorldConfig(t *testing.T) {
+	assert := assert.New(t)
+	cfg := config.Default()
+	cfg.Screen.Width, cfg.Screen.Height = 800, 600
+	cfg.Player.Lives = 5
+	cfg.Player.MoveSpeed = 50
+	cfg.Bullet.WrapRange = 400
+	rules, _ := simulation.RulesForMode(simulation.ModeClassic)
+	w := simulation.NewWorld(cfg, 1, rules)
+
+	assert.Equal(image.Rect(0, 0, 800, 600), w.Bounds)
+	assert.Equal(utils.Vector2{X: 400, Y: 300}, w.Player.Center)
+	assert.Equal(5, w.Lives)
+	assert.Equal(50.0, w.Player.Speed)
+	assert.Equal(400.0, w.BulletCtrl.Range)
+}
+
+func TestWorldStepMovesPlayer(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+
+	w.Step(sprite.Command{Thrust: true})
+	assert.InDelta(640, w.Player.Center.X, 0.0001)
+	assert.Less(w.Player.Center.Y, 360.0)
+}
+
+func TestWorldStepFire(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+
+	w.Step(sprite.Command{Fire: true})
+	assert.Equal(1, len(w.BulletCtrl.Bullets))
+
+	// the gun is rate limited
+	w.Step(sprite.Command{Fire: true})
+	assert.Equal(1, len(w.BulletCtrl.Bullets))
+}
+
+func TestWorldStepPlayerCollision(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.Lives = 1
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(w.Player.Center, 10, 0, *utils.NewVector2(1, 0)))
+
+	w.Step(sprite.Command{})
+	assert.Equal(0, w.Lives)
+	assert.True(w.IsOver())
+
+	// an over world is frozen
+	center := w.Player.Center
+	w.Step(sprite.Command{Thrust: true})
+	assert.Equal(center, w.Player.Center)
+}
+
+func TestWorldRespawn(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.Player.Center = utils.Vector2{X: 100, Y: 100}
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(w.Player.Center, 10, 0, *utils.NewVector2(1, 0)))
+	blocker := sprite.NewAsteroid(utils.Vector2{X: 700, Y: 360}, 20, 0, *utils.NewVector2(1, 0))
+	w.AsteroidCtrl.AddAsteroid(blocker)
+
+	w.Step(sprite.Command{})
+	assert.Equal(2, w.Lives)
+	assert.False(w.IsOver())
+	assert.True(w.IsRespawning())
+
+	// the ship is off the field while respawning
+	center := w.Player.Center
+	w.Step(sprite.Command{Thrust: true, Fire: true})
+	assert.Equal(center, w.Player.Center)
+	assert.Empty(w.BulletCtrl.Bullets)
+
+	// the spawn point is not clear yet
+	for range 2 * sprite.TPS {
+		w.Step(sprite.Command{})
+	}
+	assert.True(w.IsRespawning())
+
+	blocker.Destory()
+	w.Step(sprite.Command{})
+	assert.False(w.IsRespawning())
+	assert.Equal(utils.Vector2{X: 640, Y: 360}, w.Player.Center)
+	assert.True(w.Player.IsInvulnerable())
+
+	// an invulnerable ship ignores asteroids
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(w.Player.Center, 10, 0, *utils.NewVector2(1, 0)))
+	w.Step(sprite.Command{})
+	assert.Equal(2, w.Lives)
+
+	for w.Player.IsInvulnerable() {
+		w.Step(sprite.Command{})
+	}
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(w.Player.Center, 10, 0, *utils.NewVector2(1, 0)))
+	w.Step(sprite.Command{})
+	assert.Equal(1, w.Lives)
+}
+
+func TestWorldCheckBulletCollidedWithAsteroid(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 100, Y: 100}, 20, 0, *utils.NewVector2(1, 0)))
+	w.BulletCtrl.AddBullet(sprite.NewBullet(utils.Vector2{X: 110, Y: 100}, 5, 0, *utils.NewVector2(1, 0)))
+	w.BulletCtrl.AddBullet(sprite.NewBullet(utils.Vector2{X: 500, Y: 500}, 5, 0, *utils.NewVector2(1, 0)))
+
+	w.CheckBulletCollidedWithAsteroid()
+	assert.True(w.BulletCtrl.Bullets[0].IsDestoryed())
+	assert.False(w.BulletCtrl.Bullets[1].IsDestoryed())
+	assert.True(w.AsteroidCtrl.Asteroids[0].IsDestoryed())
+	assert.Equal(w.Scoring.Points(0), w.Score, "a small asteroid was destroyed")
+}
+
+func TestWorldStepDeterministic(t *testing.T) {
+	assert := assert.New(t)
+	rules, _ := simulation.RulesForMode(simulation.ModeClassic)
+	a := simulation.NewWorld(config.Default(), 7, rules)
+	b := simulation.NewWorld(config.Default(), 7, rules)
+
+	cmd := sprite.Command{RotateLeft: true, Fire: true}
+	for i := 0; i < 600; i++ {
+		a.Step(cmd)
+		b.Step(cmd)
+	}
+
+	assert.Equal(a.Clock.Ticks(), b.Clock.Ticks())
+	assert.Equal(a.IsOver(), b.IsOver())
+	assert.Equal(a.Player, b.Player)
+	assert.Equal(a.AsteroidCtrl.Asteroids, b.AsteroidCtrl.Asteroids)
+	assert.Equal(a.BulletCtrl.Bullets, b.BulletCtrl.Bullets)
+}
+
+func TestWorldFastBulletDoesNotTunnel(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 150, Y: 100}, 10, 0, *utils.NewVector2(1, 0)))
+	bullet := sprite.NewBullet(utils.Vector2{X: 100, Y: 100}, 2, 6000, *utils.NewVector2(1, 0))
+	w.BulletCtrl.AddBullet(bullet)
+
+	// the bullet jumps from x=100 to x=200, over the asteroid
+	bullet.Update()
+	w.CheckBulletCollidedWithAsteroid()
+	assert.True(bullet.IsDestoryed())
+	assert.True(w.AsteroidCtrl.Asteroids[0].IsDestoryed())
+}
+
+func TestWorldClassicWrapsEntities(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorldWithMode(simulation.ModeClassic)
+	assert.Equal(sprite.BoundaryWrap, w.Player.Boundary)
+	assert.Equal(sprite.BoundaryWrap, w.AsteroidCtrl.Boundary)
+	assert.Equal(sprite.BoundaryWrap, w.BulletCtrl.Boundary)
+	assert.Equal(sprite.FlightInertial, w.Player.Flight.Model)
+
+	w.Player.Center = utils.Vector2{X: 640, Y: 1}
+	w.Player.Velocity = utils.Vector2{X: 0, Y: -120}
+	w.Step(sprite.Command{})
+	assert.Greater(w.Player.Center.Y, 700.0, "the ship should wrap to the bottom edge")
+}
+
+func TestWorldCollisionAcrossSeam(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorldWithMode(simulation.ModeClassic)
+
+	// the asteroid pokes out of the left edge, so its image touches the bullet on the right edge
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 10, Y: 100}, 20, 0, *utils.NewVector2(1, 0)))
+	w.BulletCtrl.AddBullet(sprite.NewBullet(utils.Vector2{X: 1275, Y: 100}, 5, 0, *utils.NewVector2(1, 0)))
+	w.CheckBulletCollidedWithAsteroid()
+	assert.True(w.BulletCtrl.Bullets[0].IsDestoryed())
+
+	// the ship near the bottom edge is hit by an asteroid poking out of the top
+	w.Player.Center = utils.Vector2{X: 640, Y: 715}
+	w.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 640, Y: 10}, 20, 0, *utils.NewVector2(1, 0)))
+	assert.True(w.IsPlayerCollidedWithAsteroid())
+
+	arcade := newTestWorld()
+	arcade.AsteroidCtrl.AddAsteroid(sprite.NewAsteroid(utils.Vector2{X: 10, Y: 100}, 20, 0, *utils.NewVector2(1, 0)))
+	arcade.BulletCtrl.AddBullet(sprite.NewBullet(utils.Vector2{X: 1275, Y: 100}, 5, 0, *utils.NewVector2(1, 0)))
+	arcade.CheckBulletCollidedWithAsteroid()
+	assert.False(arcade.BulletCtrl.Bullets[0].IsDestoryed(), "arcade mode does not wrap")
+}
+
+func TestWorldWaves(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorld()
+	w.Waves = func(n int) sprite.Wave {
+		return sprite.Wave{Count: n, MinSpeed: 50, MaxSpeed: 50, SpawnRate: time.Second / 4}
+	}
+
+	n, ok := w.UpcomingWave()
+	assert.True(ok, "the game opens between waves")
+	assert.Equal(1, n)
+
+	for ok {
+		w.Step(sprite.Command{})
+		_, ok = w.UpcomingWave()
+	}
+	assert.Equal(1, w.Wave)
+	w.Step(sprite.Command{})
+	assert.Len(w.AsteroidCtrl.Asteroids, 1)
+
+	// clearing the field ends the wave
+	w.AsteroidCtrl.Asteroids[0].Destory()
+	w.Step(sprite.Command{})
+	n, ok = w.UpcomingWave()
+	assert.True(ok)
+	assert.Equal(2, n)
+
+	for ok {
+		w.Step(sprite.Command{})
+		_, ok = w.UpcomingWave()
+	}
+	for range sprite.TPS / 2 {
+		w.Step(sprite.Command{})
+	}
+	assert.Equal(2, w.Wave)
+	assert.Len(w.AsteroidCtrl.Asteroids, 2)
+}
+
+func TestWorldApplyConfig(t *testing.T) {
+	assert := assert.New(t)
+	w := newTestWorldWithMode(simulation.ModeClassic)
+	w.Waves = func(n int) sprite.Wave {
+		return sprite.Wave{
+			Count:     10,
+			MinSpeed:  40,
+			MaxSpeed:  100,
+			SpawnRate: time.Second,
+			Events:    []sprite.SpawnEvent{{MinSpeed: 20, MaxSpeed: 50}},
+		}
+	}
+	for w.Wave == 0 {
+		w.Step(sprite.Command{})
+	}
+
+	cfg := config.Default()
+	cfg.Screen.Width = 640
+	cfg.Player.MoveSpeed = 400
+	cfg.Player.Lives = 9
+	cfg.Player.FireRate = time.Second
+	cfg.Bullet.Speed = 900
+	cfg.Bullet.WrapRange = 300
+	cfg.Asteroid.MaxSpeed = 200
+	cfg.Asteroid.SpawnRate = 400 * time.Millisecond
+	w.ApplyConfig(cfg)
+
+	assert.Equal(400.0, w.Player.Speed)
+	assert.Equal(time.Second, w.Player.Gun.RateLimit)
+	assert.Equal(900.0, w.Player.Gun.Speed)
+	assert.Equal(300.0, w.BulletCtrl.Range)
+	assert.Equal(200.0, w.AsteroidCtrl.AsteroidFactory.MaxSpeed)
+	assert.Equal(1280, w.Config.Screen.Width, "the screen size only applies to new worlds")
+	assert.Equal(3, w.Lives)
+
+	wave := w.AsteroidCtrl.Wave
+	assert.Equal(40.0, wave.MinSpeed)
+	assert.Equal(200.0, wave.MaxSpeed, "the current wave is as much faster as the settings")
+	assert.Equal(500*time.Millisecond, wave.SpawnRate)
+	assert.Equal(100.0, wave.Events[0].MaxSpeed)
+
+	w.Step(sprite.Command{Fire: true})
+	if assert.Len(w.BulletCtrl.Bullets, 1) {
+		assert.Equal(900.0, w.BulletCtrl.Bullets[0].Speed, "new bullets fly at the new speed")
+	}
+}
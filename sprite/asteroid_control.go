@@ -1,12 +1,11 @@
 package sprite
 
 import (
+	"asteroid/utils"
 	"image"
 	"log"
 	"math/rand/v2"
 	"time"
-
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type AsteroidControl struct {
@@ -15,60 +14,130 @@ type AsteroidControl struct {
 	AsteroidRadiusMin int
 	AsteroidKind      int
 	Bounds            image.Rectangle
-	SpawnRate         time.Duration
-	lastSpwan         time.Time
+	Boundary          BoundaryPolicy
+	// Wave is the wave being spawned, or the last one once it is done.
+	Wave      Wave
+	Clock     Clock
+	nextSpawn time.Duration
+	// toSpawn is the number of random asteroids of Wave still to spawn.
+	toSpawn int
+	// waveStart is when Wave started, nextEvent the first of its events yet
+	// to happen.
+	waveStart time.Duration
+	nextEvent int
+	// pcg is the source of rng, kept to save its state.
+	pcg *rand.PCG
+	rng *rand.Rand
 }
 
-func NewAsteroidControl(radiusMin int, kind int, bounds image.Rectangle, spwanRate string) *AsteroidControl {
-	dur, err := time.ParseDuration(spwanRate)
-	if err != nil {
-		log.Fatal(err)
-	}
+// NewAsteroidControl creates an AsteroidControl with its own AsteroidFactory.
+// Spawned asteroids move between minSpeed and maxSpeed, deviating from the edge
+// normal by about maxAngle degrees, until StartWave sets the speeds of a wave.
+// Nothing spawns before the first wave starts. All random decisions are drawn
+// from a source seeded with seed, so equal seeds produce equal asteroid fields.
+func NewAsteroidControl(radiusMin int, kind int, bounds image.Rectangle, spawnRate time.Duration, maxSpeed float64, minSpeed float64, maxAngle float64, seed uint64, clock Clock) *AsteroidControl {
+	pcg := rand.NewPCG(seed, seed)
 	return &AsteroidControl{
-		AsteroidFactory:   NewAsteroidFactory(radiusMin, kind, bounds, 100, 40, 30),
+		AsteroidFactory:   NewAsteroidFactory(radiusMin, kind, bounds, maxSpeed, minSpeed, maxAngle),
 		AsteroidRadiusMin: radiusMin,
 		AsteroidKind:      kind,
 		Bounds:            bounds,
-		SpawnRate:         dur,
+		Wave: Wave{
+			MinSpeed:  minSpeed,
+			MaxSpeed:  maxSpeed,
+			SpawnRate: spawnRate,
+		},
+		Clock: clock,
+		pcg:   pcg,
+		rng:   rand.New(pcg),
 	}
 }
 
+// Update moves the asteroids, then spawns the ones due.
 func (c *AsteroidControl) Update() {
-	for _, a := range c.Asteroids {
+	c.Move(0, len(c.Asteroids))
+	c.Spawn()
+}
+
+// Move moves the asteroids from index lo up to hi and applies the boundary to
+// them. Asteroids are independent of each other, so ranges may be moved in
+// parallel.
+func (c *AsteroidControl) Move(lo, hi int) {
+	for _, a := range c.Asteroids[lo:hi] {
 		a.Update()
+		a.applyBoundary(c.Boundary, c.Bounds)
 	}
+}
 
-	// remove bullets that are out of bounds
-	for _, a := range c.Asteroids {
-		if a.Center.X < -float64(a.Radius) || a.Center.X > float64(c.Bounds.Max.X+a.Radius) ||
-			a.Center.Y < -float64(a.Radius) || a.Center.Y > float64(c.Bounds.Max.Y+a.Radius) {
-			a.Destory()
-		}
+// Spawn adds the asteroids of the wave which are due.
+func (c *AsteroidControl) Spawn() {
+	if now := c.Clock.Now(); c.toSpawn > 0 && now >= c.nextSpawn {
+		c.nextSpawn = now + c.Wave.SpawnRate
+		c.toSpawn--
+		c.AddAsteroid(c.SpawnAsteroid())
 	}
 
-	if time.Since(c.lastSpwan) >= c.SpawnRate {
-		c.lastSpwan = time.Now()
-		c.AddAsteroid(c.SpawnAsteroid())
+	for now := c.Clock.Now(); c.nextEvent < len(c.Wave.Events); c.nextEvent++ {
+		e := c.Wave.Events[c.nextEvent]
+		if now < c.waveStart+e.At {
+			break
+		}
+		for _, a := range c.AsteroidFactory.NewFormation(c.rng, e, c.Wave) {
+			c.AddAsteroid(a)
+		}
 	}
 }
 
-func (c *AsteroidControl) Draw(screen *ebiten.Image) {
+// StartWave spawns the asteroids of w. The first random asteroid and the
+// events at zero spawn on the next Update.
+func (c *AsteroidControl) StartWave(w Wave) {
+	c.Wave = w
+	c.toSpawn = w.Count
+	c.nextSpawn = c.Clock.Now()
+	c.waveStart = c.Clock.Now()
+	c.nextEvent = 0
+}
+
+// IsWaveCleared reports whether the whole wave has spawned and every asteroid
+// on the field, fragments included, has been destroyed.
+func (c *AsteroidControl) IsWaveCleared() bool {
+	if c.toSpawn > 0 || c.nextEvent < len(c.Wave.Events) {
+		return false
+	}
 	for _, a := range c.Asteroids {
-		a.Draw(screen)
+		if !a.IsDestoryed() {
+			return false
+		}
 	}
+	return true
+}
+
+// ForEachImage calls draw with every position a shows at.
+func (c *AsteroidControl) ForEachImage(a *Asteroid, draw func(center utils.Vector2)) {
+	forEachImage(a.Center, float64(a.Radius), c.Boundary, c.Bounds, draw)
 }
 
 func (c *AsteroidControl) AddAsteroid(a *Asteroid) {
 	c.Asteroids = append(c.Asteroids, a)
 }
 
+// SpawnAsteroid creates an asteroid of the current wave on a random edge.
 func (c *AsteroidControl) SpawnAsteroid() *Asteroid {
-	edge := rand.IntN(4)
-	return c.AsteroidFactory.NewAsteroid(edge)
+	edge := c.rng.IntN(4)
+	return c.AsteroidFactory.NewWaveAsteroid(c.rng, edge, c.Wave)
+}
+
+func randIntRange(rng *rand.Rand, min, max int) int {
+	if max <= min {
+		return min
+	}
+	return rng.IntN(max-min) + min
 }
 
-func randIntRange(min, max int) int {
-	return rand.IntN(max-min) + min
+// Tier returns the size tier of a, from 0 for the smallest asteroids up to
+// AsteroidKind-1 for the largest.
+func (c *AsteroidControl) Tier(a *Asteroid) int {
+	return utils.Clamp(a.Radius/c.AsteroidRadiusMin-1, 0, c.AsteroidKind-1)
 }
 
 func (c *AsteroidControl) HitAsteroid(i int) {
@@ -79,7 +148,7 @@ func (c *AsteroidControl) HitAsteroid(i int) {
 	if c.Asteroids[i].Radius > c.AsteroidRadiusMin {
 		newRadius := c.Asteroids[i].Radius - c.AsteroidRadiusMin
 		newSpeed := c.Asteroids[i].Speed * 1.2
-		newAngel := rand.Float64()*30 + 20
+		newAngel := c.rng.Float64()*30 + 20
 		newDirection1 := c.Asteroids[i].Direction.Clone().Rotate(newAngel)
 		newDirection2 := c.Asteroids[i].Direction.Clone().Rotate(-newAngel)
 		newCenter1 := c.Asteroids[i].Center.Clone().Add(*newDirection1.Clone().Scale(float64(c.Asteroids[i].Radius)))
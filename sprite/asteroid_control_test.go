@@ -3,6 +3,7 @@ package sprite_test
 import (
 	"image"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -14,39 +15,60 @@ func TestNewAsteroidControl(t *testing.T) {
 	minRadius := 20
 	kind := 3
 	bounds := image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}
-	spawnRate := "1s"
+	spawnRate := time.Second
+	maxSpeed := 90.0
+	minSpeed := 50.0
+	maxAngle := 10.0
+	clock := &sprite.FakeClock{}
 
-	asteroidControl := sprite.NewAsteroidControl(minRadius, kind, bounds, spawnRate)
+	asteroidControl := sprite.NewAsteroidControl(minRadius, kind, bounds, spawnRate, maxSpeed, minSpeed, maxAngle, 1, clock)
 	assert := assert.New(t)
 	assert.NotNil(asteroidControl)
 	assert.Equal(minRadius, asteroidControl.AsteroidRadiusMin)
 	assert.Equal(kind, asteroidControl.AsteroidKind)
 	assert.Equal(bounds, asteroidControl.Bounds)
-	assert.Equal(spawnRate, asteroidControl.SpawnRate.String())
+	assert.Equal(spawnRate, asteroidControl.Wave.SpawnRate)
+	assert.Equal(maxSpeed, asteroidControl.Wave.MaxSpeed)
+	assert.Equal(minSpeed, asteroidControl.Wave.MinSpeed)
+	assert.Equal(clock, asteroidControl.Clock)
+	assert.Equal(maxSpeed, asteroidControl.AsteroidFactory.MaxSpeed)
+	assert.Equal(minSpeed, asteroidControl.AsteroidFactory.MinSpeed)
+	assert.Equal(maxAngle, asteroidControl.AsteroidFactory.MaxAngle)
 }
 
 func TestAsteroidControlUpdate(t *testing.T) {
-	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, "1s")
-	ac.Update()
+	clock := &sprite.FakeClock{}
+	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, clock)
 	assert := assert.New(t)
+	ac.Update()
+	assert.Equal(0, len(ac.Asteroids), "nothing spawns before the first wave")
+
+	ac.StartWave(sprite.Wave{Count: 10, MinSpeed: 40, MaxSpeed: 100, SpawnRate: time.Second})
+	ac.Update()
 	assert.Equal(1, len(ac.Asteroids))
 
 	ac.AddAsteroid(&sprite.Asteroid{Circle: sprite.Circle{Center: utils.Vector2{X: -100, Y: -100}}})
 	assert.Equal(2, len(ac.Asteroids))
+	clock.Advance(time.Millisecond * 999)
 	ac.Update()
 	assert.Equal(2, len(ac.Asteroids))
 	assert.Equal(true, ac.Asteroids[1].IsDestoryed())
+
+	// the next asteroid spawns once the spawn rate has elapsed
+	clock.Advance(time.Millisecond)
+	ac.Update()
+	assert.Equal(3, len(ac.Asteroids))
 }
 
 func TestAddAsteroid(t *testing.T) {
-	asteroidControl := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, "1s")
+	asteroidControl := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, &sprite.FakeClock{})
 	asteroidControl.AddAsteroid(&sprite.Asteroid{})
 
 	assert.Equal(t, 1, len(asteroidControl.Asteroids))
 }
 
 func TestAsteroidControlHitAsteroid(t *testing.T) {
-	asteroidControl := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, "1s")
+	asteroidControl := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, &sprite.FakeClock{})
 	asteroidControl.AddAsteroid(&sprite.Asteroid{Circle: sprite.Circle{Radius: 40}})
 	asteroidControl.AddAsteroid(&sprite.Asteroid{Circle: sprite.Circle{Radius: 20}})
 	asteroidControl.AddAsteroid(&sprite.Asteroid{Circle: sprite.Circle{Radius: 20}})
@@ -65,7 +87,7 @@ func TestAsteroidControlHitAsteroid(t *testing.T) {
 }
 
 func TestAsteroidControlClean(t *testing.T) {
-	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, "1s")
+	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, &sprite.FakeClock{})
 	ac.AddAsteroid(&sprite.Asteroid{})
 	ac.AddAsteroid(&sprite.Asteroid{})
 
@@ -78,9 +100,124 @@ func TestAsteroidControlClean(t *testing.T) {
 }
 
 func TestAsteroidControlSpawnAsteroid(t *testing.T) {
-	asteroidControl := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, "1s")
+	asteroidControl := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, &sprite.FakeClock{})
 	asteroid := asteroidControl.SpawnAsteroid()
 
 	assert := assert.New(t)
 	assert.NotNil(asteroid)
 }
+
+func TestAsteroidControlSeed(t *testing.T) {
+	bounds := image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}
+	a := sprite.NewAsteroidControl(20, 3, bounds, time.Second, 100, 40, 30, 42, &sprite.FakeClock{})
+	b := sprite.NewAsteroidControl(20, 3, bounds, time.Second, 100, 40, 30, 42, &sprite.FakeClock{})
+	c := sprite.NewAsteroidControl(20, 3, bounds, time.Second, 100, 40, 30, 43, &sprite.FakeClock{})
+
+	assert := assert.New(t)
+	for i := 0; i < 10; i++ {
+		a.AddAsteroid(a.SpawnAsteroid())
+		b.AddAsteroid(b.SpawnAsteroid())
+		c.AddAsteroid(c.SpawnAsteroid())
+	}
+	a.HitAsteroid(0)
+	b.HitAsteroid(0)
+	c.HitAsteroid(0)
+
+	assert.Equal(a.Asteroids, b.Asteroids, "same seed should produce the same asteroids")
+	assert.NotEqual(a.Asteroids, c.Asteroids, "different seeds should produce different asteroids")
+}
+
+func TestAsteroidControlUpdateBoundary(t *testing.T) {
+	bounds := image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}
+	cases := []struct {
+		name      string
+		boundary  sprite.BoundaryPolicy
+		center    utils.Vector2
+		destroyed bool
+	}{
+		{"destroy", sprite.BoundaryDestroy, utils.Vector2{X: -100, Y: 500}, true},
+		{"clamp", sprite.BoundaryClamp, utils.Vector2{X: 0, Y: 500}, false},
+		{"wrap", sprite.BoundaryWrap, utils.Vector2{X: 900, Y: 500}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ac := sprite.NewAsteroidControl(20, 3, bounds, time.Second, 100, 40, 30, 1, &sprite.FakeClock{})
+			ac.Boundary = c.boundary
+			ac.AddAsteroid(&sprite.Asteroid{Circle: sprite.Circle{Center: utils.Vector2{X: -100, Y: 500}, Radius: 20}})
+			ac.Update()
+
+			assert.Equal(t, c.destroyed, ac.Asteroids[0].IsDestoryed())
+			assert.Equal(t, c.center, ac.Asteroids[0].Center)
+		})
+	}
+}
+
+func TestAsteroidControlTier(t *testing.T) {
+	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, &sprite.FakeClock{})
+
+	assert := assert.New(t)
+	assert.Equal(0, ac.Tier(&sprite.Asteroid{Circle: sprite.Circle{Radius: 20}}))
+	assert.Equal(1, ac.Tier(&sprite.Asteroid{Circle: sprite.Circle{Radius: 40}}))
+	assert.Equal(2, ac.Tier(&sprite.Asteroid{Circle: sprite.Circle{Radius: 60}}))
+	assert.Equal(2, ac.Tier(&sprite.Asteroid{Circle: sprite.Circle{Radius: 200}}))
+	assert.Equal(0, ac.Tier(&sprite.Asteroid{Circle: sprite.Circle{Radius: 5}}))
+}
+
+func TestAsteroidControlWave(t *testing.T) {
+	assert := assert.New(t)
+	clock := &sprite.FakeClock{}
+	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, clock)
+	ac.StartWave(sprite.Wave{Count: 3, Sizes: []int{0, 0, 1}, MinSpeed: 70, MaxSpeed: 70, SpawnRate: time.Second})
+	assert.False(ac.IsWaveCleared())
+
+	for range 5 {
+		ac.Update()
+		clock.Advance(time.Second)
+	}
+	assert.Len(ac.Asteroids, 3, "the wave stops after Count asteroids")
+	for _, a := range ac.Asteroids {
+		assert.Equal(60, a.Radius)
+		assert.Equal(70.0, a.Speed)
+	}
+
+	ac.HitAsteroid(0)
+	ac.Asteroids[1].Destory()
+	ac.Asteroids[2].Destory()
+	ac.Clean()
+	assert.False(ac.IsWaveCleared(), "the fragments are part of the wave")
+
+	for _, a := range ac.Asteroids {
+		a.Destory()
+	}
+	assert.True(ac.IsWaveCleared())
+}
+
+func TestAsteroidControlWaveEvents(t *testing.T) {
+	assert := assert.New(t)
+	clock := &sprite.FakeClock{}
+	ac := sprite.NewAsteroidControl(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, time.Second, 100, 40, 30, 1, clock)
+	clock.Set(10 * time.Second)
+	ac.StartWave(sprite.Wave{
+		MinSpeed: 40,
+		MaxSpeed: 100,
+		Events: []sprite.SpawnEvent{
+			{At: 0, Edge: sprite.EdgeTop, Count: 2},
+			{At: 2 * time.Second, Edge: sprite.EdgeLeft, Count: 3, Formation: sprite.FormationLine, Spacing: 50},
+		},
+	})
+
+	ac.Update()
+	assert.Len(ac.Asteroids, 2)
+	clock.Advance(1999 * time.Millisecond)
+	ac.Update()
+	assert.Len(ac.Asteroids, 2, "events happen relative to the start of the wave")
+	clock.Advance(time.Millisecond)
+	ac.Update()
+	assert.Len(ac.Asteroids, 5)
+
+	for _, a := range ac.Asteroids {
+		a.Destory()
+	}
+	assert.True(ac.IsWaveCleared())
+}
@@ -16,44 +16,115 @@ type AsteroidFactory struct {
 	MaxAngle  float64
 }
 
-var factory *AsteroidFactory
-
 func NewAsteroidFactory(minRadius int, kind int, bounds image.Rectangle, maxSpeed float64, minSpeed float64, maxAngle float64) *AsteroidFactory {
-	if factory == nil {
-		factory = &AsteroidFactory{
-			MinRadius: minRadius,
-			Kind:      kind,
-			Bounds:    bounds,
-			MaxSpeed:  maxSpeed,
-			MinSpeed:  minSpeed,
-			MaxAngle:  maxAngle,
-		}
+	return &AsteroidFactory{
+		MinRadius: minRadius,
+		Kind:      kind,
+		Bounds:    bounds,
+		MaxSpeed:  maxSpeed,
+		MinSpeed:  minSpeed,
+		MaxAngle:  maxAngle,
 	}
-	return factory
 }
 
-func (af *AsteroidFactory) NewAsteroid(edge int) *Asteroid {
-	radius := (rand.IntN(af.Kind) + 1) * af.MinRadius
-	speed := float64(randIntRange(int(af.MinSpeed), int(af.MaxSpeed)))
-	angle := rand.NormFloat64() * af.MaxAngle
+// NewAsteroid creates an asteroid of any size on the given edge, drawing every
+// random value from rng.
+func (af *AsteroidFactory) NewAsteroid(rng *rand.Rand, edge int) *Asteroid {
+	return af.NewWaveAsteroid(rng, edge, Wave{MinSpeed: af.MinSpeed, MaxSpeed: af.MaxSpeed})
+}
+
+// NewWaveAsteroid creates an asteroid on the given edge with the size mix and
+// speeds of w.
+func (af *AsteroidFactory) NewWaveAsteroid(rng *rand.Rand, edge int, w Wave) *Asteroid {
+	radius := (w.tier(rng, af.Kind) + 1) * af.MinRadius
+	speed := float64(randIntRange(rng, int(w.MinSpeed), int(w.MaxSpeed)))
+	angle := rng.NormFloat64() * af.MaxAngle
+
+	lo, hi := af.span(edge, radius)
+	center, direction := af.edgeAt(edge, float64(randIntRange(rng, lo, hi)), radius)
+	return NewAsteroid(center, radius, speed, *direction.Rotate(angle))
+}
+
+// NewFormation creates the asteroids of the spawn event e of wave w.
+func (af *AsteroidFactory) NewFormation(rng *rand.Rand, e SpawnEvent, w Wave) []*Asteroid {
+	edge := e.Edge
+	if edge == EdgeRandom {
+		edge = rng.IntN(4)
+	}
+	if e.MinSpeed != 0 || e.MaxSpeed != 0 {
+		w.MinSpeed, w.MaxSpeed = e.MinSpeed, e.MaxSpeed
+	}
+	if e.Size > 0 {
+		// a single tier of weight
+		w.Sizes = make([]int, e.Size)
+		w.Sizes[e.Size-1] = 1
+	}
+
+	asteroids := make([]*Asteroid, 0, e.Count)
+	if e.Formation == FormationScatter {
+		for range e.Count {
+			asteroids = append(asteroids, af.NewWaveAsteroid(rng, edge, w))
+		}
+		return asteroids
+	}
+
+	radii := make([]int, e.Count)
+	largest := 0
+	for i := range radii {
+		radii[i] = (w.tier(rng, af.Kind) + 1) * af.MinRadius
+		largest = max(largest, radii[i])
+	}
+	speed := float64(randIntRange(rng, int(w.MinSpeed), int(w.MaxSpeed)))
+	angle := rng.NormFloat64() * af.MaxAngle
 
-	var center utils.Vector2
-	var direction *utils.Vector2
+	// the middle of the formation, so all of it fits on the edge
+	width := 0.0
+	if e.Formation == FormationLine {
+		width = e.Spacing * float64(e.Count-1)
+	}
+	lo, hi := af.span(edge, largest)
+	mid := float64(lo+hi) / 2
+	if free := float64(hi-lo) - width; free > 0 {
+		mid = float64(lo) + width/2 + rng.Float64()*free
+	}
 
+	for i, radius := range radii {
+		offset := float64(i) - float64(e.Count-1)/2
+		along, heading := mid, angle
+		switch e.Formation {
+		case FormationLine:
+			along += offset * e.Spacing
+		case FormationFan:
+			heading += offset * e.Spacing
+		}
+		center, direction := af.edgeAt(edge, along, radius)
+		asteroids = append(asteroids, NewAsteroid(center, radius, speed, *direction.Rotate(heading)))
+	}
+	return asteroids
+}
+
+// span returns the range of positions along edge at which an asteroid of
+// radius spawns fully inside the bounds.
+func (af *AsteroidFactory) span(edge int, radius int) (lo, hi int) {
+	switch edge {
+	case EdgeTop, EdgeBottom:
+		return radius, af.Bounds.Max.X - radius
+	default:
+		return radius, af.Bounds.Max.Y - radius
+	}
+}
+
+// edgeAt returns the center of an asteroid of radius spawning on edge at along,
+// its position along the edge, and the direction pointing into the bounds.
+func (af *AsteroidFactory) edgeAt(edge int, along float64, radius int) (utils.Vector2, *utils.Vector2) {
 	switch edge {
-	case 0:
-		center = utils.Vector2{X: float64(randIntRange(radius, af.Bounds.Max.X-radius)), Y: 0}
-		direction = utils.NewVector2(0, 1).Rotate(angle)
-	case 1:
-		center = utils.Vector2{X: float64(af.Bounds.Max.X - radius), Y: float64(randIntRange(radius, af.Bounds.Max.Y-radius))}
-		direction = utils.NewVector2(-1, 0).Rotate(angle)
-	case 2:
-		center = utils.Vector2{X: float64(randIntRange(radius, af.Bounds.Max.X-radius)), Y: float64(af.Bounds.Max.Y - radius)}
-		direction = utils.NewVector2(0, -1).Rotate(angle)
-	case 3:
-		center = utils.Vector2{X: float64(af.Bounds.Min.X + radius), Y: float64(randIntRange(radius, af.Bounds.Max.Y-radius))}
-		direction = utils.NewVector2(1, 0).Rotate(angle)
-	}
-
-	return NewAsteroid(center, radius, speed, *direction)
+	case EdgeTop:
+		return utils.Vector2{X: along, Y: 0}, utils.NewVector2(0, 1)
+	case EdgeRight:
+		return utils.Vector2{X: float64(af.Bounds.Max.X - radius), Y: along}, utils.NewVector2(-1, 0)
+	case EdgeBottom:
+		return utils.Vector2{X: along, Y: float64(af.Bounds.Max.Y - radius)}, utils.NewVector2(0, -1)
+	default:
+		return utils.Vector2{X: float64(af.Bounds.Min.X + radius), Y: along}, utils.NewVector2(1, 0)
+	}
 }
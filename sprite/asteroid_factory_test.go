@@ -2,6 +2,8 @@ package sprite_test
 
 import (
 	"image"
+	"math"
+	"math/rand/v2"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,20 @@ func TestAsteroidNewFactory(t *testing.T) {
 	assert.Equal(maxAngle, factory.MaxAngle)
 }
 
+func TestAsteroidNewFactoryIndependent(t *testing.T) {
+	small := sprite.NewAsteroidFactory(10, 2, image.Rect(0, 0, 640, 480), 50.0, 20.0, 15.0)
+	large := sprite.NewAsteroidFactory(30, 4, image.Rect(0, 0, 1920, 1080), 200.0, 80.0, 45.0)
+
+	assert := assert.New(t)
+	assert.NotSame(small, large)
+	assert.Equal(10, small.MinRadius)
+	assert.Equal(image.Rect(0, 0, 640, 480), small.Bounds)
+	assert.Equal(50.0, small.MaxSpeed)
+	assert.Equal(30, large.MinRadius)
+	assert.Equal(image.Rect(0, 0, 1920, 1080), large.Bounds)
+	assert.Equal(200.0, large.MaxSpeed)
+}
+
 func TestAsteroidNewAestroid(t *testing.T) {
 	factory := sprite.NewAsteroidFactory(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, 100.0, 40.0, 30.0)
 
@@ -86,10 +102,11 @@ func TestAsteroidNewAestroid(t *testing.T) {
 		},
 	}
 
+	rng := rand.New(rand.NewPCG(1, 1))
 	assert := assert.New(t)
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			asteroid := factory.NewAsteroid(c.edge)
+			asteroid := factory.NewAsteroid(rng, c.edge)
 			assert.NotNil(asteroid)
 			assert.Contains(c.expected.radius, asteroid.Radius)
 
@@ -103,3 +120,52 @@ func TestAsteroidNewAestroid(t *testing.T) {
 		})
 	}
 }
+
+func TestAsteroidNewFormation(t *testing.T) {
+	factory := sprite.NewAsteroidFactory(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, 100.0, 40.0, 30.0)
+	wave := sprite.Wave{MinSpeed: 40, MaxSpeed: 100}
+	rng := rand.New(rand.NewPCG(1, 1))
+	assert := assert.New(t)
+
+	t.Run("scatter", func(t *testing.T) {
+		e := sprite.SpawnEvent{Edge: sprite.EdgeLeft, Size: 2, Count: 4, MinSpeed: 150, MaxSpeed: 160}
+		asteroids := factory.NewFormation(rng, e, wave)
+		assert.Len(asteroids, 4)
+		for _, a := range asteroids {
+			assert.Equal(40, a.Radius)
+			assert.Equal(40.0, a.Center.X)
+			assert.GreaterOrEqual(a.Speed, 150.0)
+			assert.Less(a.Speed, 160.0)
+		}
+	})
+
+	t.Run("line", func(t *testing.T) {
+		e := sprite.SpawnEvent{Edge: sprite.EdgeTop, Size: 1, Count: 3, Formation: sprite.FormationLine, Spacing: 100}
+		asteroids := factory.NewFormation(rng, e, wave)
+		assert.Len(asteroids, 3)
+		for i, a := range asteroids {
+			assert.Equal(0.0, a.Center.Y)
+			assert.GreaterOrEqual(a.Center.X, 20.0)
+			assert.LessOrEqual(a.Center.X, 980.0)
+			assert.Equal(asteroids[0].Speed, a.Speed)
+			assert.Equal(asteroids[0].Direction, a.Direction)
+			if i > 0 {
+				assert.InDelta(100, a.Center.X-asteroids[i-1].Center.X, 0.0001)
+			}
+		}
+	})
+
+	t.Run("fan", func(t *testing.T) {
+		e := sprite.SpawnEvent{Edge: sprite.EdgeBottom, Size: 3, Count: 3, Formation: sprite.FormationFan, Spacing: 20}
+		asteroids := factory.NewFormation(rng, e, wave)
+		assert.Len(asteroids, 3)
+		for i, a := range asteroids {
+			assert.Equal(asteroids[0].Center, a.Center)
+			assert.Equal(940.0, a.Center.Y)
+			if i > 0 {
+				angle := math.Acos(a.Direction.X*asteroids[i-1].Direction.X+a.Direction.Y*asteroids[i-1].Direction.Y) * 180 / math.Pi
+				assert.InDelta(20, angle, 0.0001)
+			}
+		}
+	})
+}
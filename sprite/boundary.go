@@ -0,0 +1,62 @@
+package sprite
+
+import (
+	"image"
+
+	"asteroid/physics"
+	"asteroid/utils"
+)
+
+// BoundaryPolicy decides what happens to an entity reaching the edge of its bounds.
+type BoundaryPolicy int
+
+const (
+	// BoundaryDestroy destroys the entity once it is fully out of bounds.
+	BoundaryDestroy BoundaryPolicy = iota
+	// BoundaryClamp keeps the center of the entity inside the bounds.
+	BoundaryClamp
+	// BoundaryWrap moves the entity to the opposite edge, as if the bounds were a torus.
+	BoundaryWrap
+)
+
+var boundaryPolicyNames = [...]string{
+	BoundaryDestroy: "destroy",
+	BoundaryClamp:   "clamp",
+	BoundaryWrap:    "wrap",
+}
+
+func (b BoundaryPolicy) String() string {
+	if b < 0 || int(b) >= len(boundaryPolicyNames) {
+		return "unknown"
+	}
+	return boundaryPolicyNames[b]
+}
+
+// applyBoundary applies policy to c once it has moved within bounds.
+func (c *Circle) applyBoundary(policy BoundaryPolicy, bounds image.Rectangle) {
+	switch policy {
+	case BoundaryDestroy:
+		r := c.Radius
+		if c.Center.X < float64(bounds.Min.X-r) || c.Center.X > float64(bounds.Max.X+r) ||
+			c.Center.Y < float64(bounds.Min.Y-r) || c.Center.Y > float64(bounds.Max.Y+r) {
+			c.Destory()
+		}
+	case BoundaryClamp:
+		c.Center.Clamp(bounds)
+	case BoundaryWrap:
+		c.Center.Wrap(bounds)
+	}
+}
+
+// forEachImage calls draw with every position an entity at center shows at. Wrapping entities overlapping an edge are drawn on both sides of it.
+func forEachImage(center utils.Vector2, radius float64, policy BoundaryPolicy, bounds image.Rectangle, draw func(center utils.Vector2)) {
+	if policy != BoundaryWrap {
+		draw(center)
+		return
+	}
+
+	var buf [4]utils.Vector2
+	for _, o := range physics.WrapOffsets(center, radius, bounds, buf[:0]) {
+		draw(*center.Clone().Add(o))
+	}
+}
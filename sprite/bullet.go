@@ -2,14 +2,12 @@ package sprite
 
 import (
 	"asteroid/utils"
-	"image/color"
-
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 type Bullet struct {
 	Circle
+
+	travelled float64
 }
 
 func NewBullet(center utils.Vector2, radius int, speed float64, direction utils.Vector2) *Bullet {
@@ -25,8 +23,10 @@ func NewBullet(center utils.Vector2, radius int, speed float64, direction utils.
 
 func (b *Bullet) Update() {
 	b.Center.Add(*b.Direction.Clone().Scale(b.Speed * dt))
+	b.travelled += b.Speed * dt
 }
 
-func (b *Bullet) Draw(screen *ebiten.Image) {
-	vector.DrawFilledCircle(screen, float32(b.Center.X), float32(b.Center.Y), float32(b.Radius), color.White, true)
+// Travelled returns the distance covered by the bullet since it was fired.
+func (b *Bullet) Travelled() float64 {
+	return b.travelled
 }
@@ -1,15 +1,18 @@
 package sprite
 
 import (
+	"asteroid/utils"
 	"image"
 	"log"
-
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type BulletControl struct {
-	Bounds  image.Rectangle
-	Bullets []*Bullet
+	Bounds   image.Rectangle
+	Bullets  []*Bullet
+	Boundary BoundaryPolicy
+	// Range is the distance after which bullets are destroyed, 0 means unlimited.
+	// Wrapping bullets need one so they do not fly forever.
+	Range float64
 }
 
 func NewBulletControl(bounds image.Rectangle) *BulletControl {
@@ -47,20 +50,22 @@ func (bc *BulletControl) Clean() {
 	bc.Bullets = bc.Bullets[:mark]
 }
 
-func (bc *BulletControl) Draw(screen *ebiten.Image) {
-	for _, b := range bc.Bullets {
-		b.Draw(screen)
-	}
+// ForEachImage calls draw with every position b shows at.
+func (bc *BulletControl) ForEachImage(b *Bullet, draw func(center utils.Vector2)) {
+	forEachImage(b.Center, float64(b.Radius), bc.Boundary, bc.Bounds, draw)
 }
 
 func (bc *BulletControl) Update() {
-	for _, b := range bc.Bullets {
+	bc.Move(0, len(bc.Bullets))
+}
+
+// Move moves the bullets from index lo up to hi, applies the boundary to them
+// and destroys the ones out of range. Ranges may be moved in parallel.
+func (bc *BulletControl) Move(lo, hi int) {
+	for _, b := range bc.Bullets[lo:hi] {
 		b.Update()
-	}
-	// remove bullets that are out of bounds
-	for _, b := range bc.Bullets {
-		if b.Center.X < -float64(b.Radius) || b.Center.X > float64(bc.Bounds.Max.X+b.Radius) ||
-			b.Center.Y < -float64(b.Radius) || b.Center.Y > float64(bc.Bounds.Max.Y+b.Radius) {
+		b.applyBoundary(bc.Boundary, bc.Bounds)
+		if bc.Range > 0 && b.travelled >= bc.Range {
 			b.Destory()
 		}
 	}
@@ -84,3 +84,21 @@ func TestBulletControlUpdate(t *testing.T) {
 	assert.NotEqual(oldCenter, bc.Bullets[0].Circle.Center)
 	assert.Equal(true, bc.Bullets[1].IsDestoryed())
 }
+
+func TestBulletControlUpdateWrap(t *testing.T) {
+	bounds := image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}
+	bc := sprite.NewBulletControl(bounds)
+	bc.Boundary = sprite.BoundaryWrap
+	bc.Range = 20
+
+	bc.AddBullet(sprite.NewBullet(utils.Vector2{X: 999, Y: 100}, 5, 600, utils.Vector2{X: 1, Y: 0}))
+
+	assert := assert.New(t)
+	bc.Update()
+	assert.InDelta(9, bc.Bullets[0].Center.X, 0.0001, "the bullet should wrap to the left edge")
+	assert.False(bc.Bullets[0].IsDestoryed())
+
+	bc.Update()
+	assert.InDelta(20, bc.Bullets[0].Travelled(), 0.0001)
+	assert.True(bc.Bullets[0].IsDestoryed(), "the bullet should vanish once out of range")
+}
@@ -122,3 +122,26 @@ func TestBulletDestory(t *testing.T) {
 	b.Destory()
 	assert.Equal(true, b.IsDestoryed())
 }
+
+func TestBulletPrevCenter(t *testing.T) {
+	bullet := sprite.NewBullet(utils.Vector2{X: 100, Y: 100}, 5, 600, utils.Vector2{X: 1, Y: 0})
+	bullet.Update()
+
+	prev := bullet.PrevCenter()
+	assert.InDelta(t, 100, prev.X, 0.0001)
+	assert.InDelta(t, 100, prev.Y, 0.0001)
+}
+
+func TestBulletIsSweptCollided(t *testing.T) {
+	assert := assert.New(t)
+	// 6000px/s moves the bullet 100px per tick
+	bullet := sprite.NewBullet(utils.Vector2{X: 0, Y: 100}, 2, 6000, utils.Vector2{X: 1, Y: 0})
+	asteroid := sprite.NewAsteroid(utils.Vector2{X: 50, Y: 100}, 10, 0, utils.Vector2{X: 0, Y: 1})
+	bullet.Update()
+
+	assert.False(bullet.IsCollided(asteroid), "the end position misses the asteroid")
+	assert.True(bullet.IsSweptCollided(&asteroid.Circle), "the travelled segment crosses the asteroid")
+
+	miss := sprite.NewAsteroid(utils.Vector2{X: 50, Y: 150}, 10, 0, utils.Vector2{X: 0, Y: 1})
+	assert.False(bullet.IsSweptCollided(&miss.Circle))
+}
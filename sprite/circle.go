@@ -1,6 +1,9 @@
 package sprite
 
-import "asteroid/utils"
+import (
+	"asteroid/physics"
+	"asteroid/utils"
+)
 
 type Circle struct {
 	Center    utils.Vector2
@@ -14,12 +17,24 @@ func (c *Circle) GetHitboxCircule() (p utils.Vector2, r int) {
 	return c.Center, c.Radius
 }
 
+func (c *Circle) Hitbox() physics.Shape {
+	return physics.Circle{Center: c.Center, Radius: float64(c.Radius)}
+}
+
 func (c *Circle) IsCollided(h Collidable) bool {
-	bPos, bRad := c.GetHitboxCircule()
-	hPos, hRad := h.GetHitboxCircule()
+	return physics.Overlaps(c.Hitbox(), h.Hitbox())
+}
+
+// PrevCenter returns where the circle was one tick ago, given it moves Speed
+// pixels per second along Direction.
+func (c *Circle) PrevCenter() utils.Vector2 {
+	return *c.Center.Clone().Sub(*c.Direction.Clone().Scale(c.Speed * dt))
+}
 
-	dist := utils.Distance(bPos.X, bPos.Y, hPos.X, hPos.Y)
-	return dist <= float64(bRad+hRad)
+// IsSweptCollided reports whether c and h touched at any time during the last
+// tick, testing the segments they travelled instead of their end positions only.
+func (c *Circle) IsSweptCollided(h *Circle) bool {
+	return physics.SweptCircles(c.PrevCenter(), c.Center, float64(c.Radius), h.PrevCenter(), h.Center, float64(h.Radius))
 }
 
 func (c *Circle) IsDestoryed() bool {
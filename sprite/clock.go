@@ -0,0 +1,56 @@
+package sprite
+
+import "time"
+
+// Clock reports the simulation time elapsed since the start of a game.
+type Clock interface {
+	Now() time.Duration
+}
+
+// TickClock is a Clock driven by the fixed simulation tick. It only moves when
+// Tick is called, so it stays in step with movement no matter the actual TPS.
+type TickClock struct {
+	ticks uint64
+}
+
+func NewTickClock() *TickClock {
+	return &TickClock{}
+}
+
+// Tick advances the clock by one simulation tick.
+func (c *TickClock) Tick() {
+	c.ticks++
+}
+
+// Ticks returns the number of ticks elapsed.
+func (c *TickClock) Ticks() uint64 {
+	return c.ticks
+}
+
+// Set moves the clock to ticks, for resuming a saved game.
+func (c *TickClock) Set(ticks uint64) {
+	c.ticks = ticks
+}
+
+func (c *TickClock) Now() time.Duration {
+	return time.Duration(c.ticks) * time.Second / TPS
+}
+
+// FakeClock is a Clock that is set by hand, for tests and replays.
+type FakeClock struct {
+	now time.Duration
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.now += d
+}
+
+// Set moves the clock to t.
+func (c *FakeClock) Set(t time.Duration) {
+	c.now = t
+}
+
+func (c *FakeClock) Now() time.Duration {
+	return c.now
+}
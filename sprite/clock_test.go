@@ -0,0 +1,37 @@
+package sprite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/sprite"
+)
+
+func TestTickClock(t *testing.T) {
+	assert := assert.New(t)
+	clock := sprite.NewTickClock()
+	assert.Equal(time.Duration(0), clock.Now())
+
+	for i := 0; i < sprite.TPS; i++ {
+		clock.Tick()
+	}
+	assert.Equal(uint64(sprite.TPS), clock.Ticks())
+	assert.Equal(time.Second, clock.Now())
+
+	clock.Tick()
+	assert.Equal(time.Second+time.Second/sprite.TPS, clock.Now())
+}
+
+func TestFakeClock(t *testing.T) {
+	assert := assert.New(t)
+	clock := &sprite.FakeClock{}
+	assert.Equal(time.Duration(0), clock.Now())
+
+	clock.Advance(time.Second)
+	assert.Equal(time.Second, clock.Now())
+
+	clock.Set(time.Millisecond)
+	assert.Equal(time.Millisecond, clock.Now())
+}
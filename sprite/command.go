@@ -0,0 +1,11 @@
+package sprite
+
+// Command is what the ship is told to do during a single tick, by whoever is
+// flying it.
+type Command struct {
+	Thrust      bool
+	Reverse     bool
+	RotateLeft  bool
+	RotateRight bool
+	Fire        bool
+}
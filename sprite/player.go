@@ -1,13 +1,11 @@
 package sprite
 
 import (
+	"asteroid/physics"
 	"asteroid/utils"
 	"errors"
 	"image"
 	"time"
-
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 var ErrGunNotReady = errors.New("gun is not ready yet")
@@ -25,6 +23,24 @@ const (
 	MoveBackward
 )
 
+// FlightModel is how the ship responds to thrust.
+type FlightModel int
+
+const (
+	// FlightArcade moves the ship at Speed only while thrusting.
+	FlightArcade FlightModel = iota
+	// FlightInertial accelerates the ship, which keeps drifting once thrust stops.
+	FlightInertial
+)
+
+type FlightConfig struct {
+	Model FlightModel
+	// Thrust is the acceleration of the inertial model, in pixels per second squared.
+	Thrust float64
+	// Drag is the fraction of its velocity the ship loses per second in the inertial model.
+	Drag float64
+}
+
 type RotateDirection int
 
 const (
@@ -32,18 +48,31 @@ const (
 	RotateClockwise
 )
 
+// blinkPeriod is how long the ship is shown, then hidden, while invulnerable.
+const blinkPeriod = 100 * time.Millisecond
+
 type Player struct {
 	Circle
 
 	Bounds        image.Rectangle
 	RotationSpeed float64
+	// Boundary is BoundaryWrap to wrap the ship around the screen, any other
+	// policy keeps it inside Bounds.
+	Boundary BoundaryPolicy
 
-	Gun GunConfig
+	Gun    GunConfig
+	Flight FlightConfig
+	// Velocity is the drift of the ship in pixels per second, used by FlightInertial.
+	Velocity utils.Vector2
+	Clock    Clock
 
-	lastFired time.Time
+	nextFire          time.Duration
+	invulnerableUntil time.Duration
 }
 
-func NewPlayer(center utils.Vector2, radius int, bounds image.Rectangle, speed float64, rotationSpeed float64, gun GunConfig) *Player {
+// NewPlayer creates the ship. With FlightArcade speed is how fast it moves
+// while thrusting, with FlightInertial it is the maximum speed it can reach.
+func NewPlayer(center utils.Vector2, radius int, bounds image.Rectangle, speed float64, rotationSpeed float64, gun GunConfig, flight FlightConfig, clock Clock) *Player {
 	newBounds := image.Rectangle{
 		Min: image.Point{X: bounds.Min.X + radius, Y: bounds.Min.Y + radius},
 		Max: image.Point{X: bounds.Max.X - radius, Y: bounds.Max.Y - radius},
@@ -58,70 +87,140 @@ func NewPlayer(center utils.Vector2, radius int, bounds image.Rectangle, speed f
 		Bounds:        newBounds,
 		RotationSpeed: rotationSpeed,
 		Gun:           gun,
+		Flight:        flight,
+		Clock:         clock,
 	}
 
 	return &p
 }
 
-func (p *Player) Update(keys []ebiten.Key) {
-	for _, k := range keys {
-		switch k {
-		case ebiten.KeyW:
-			p.Move(MoveForward, p.Speed*dt)
-		case ebiten.KeyS:
-			p.Move(MoveBackward, p.Speed*dt)
-		case ebiten.KeyA:
-			p.Rotate(RotateAntiClockwise, p.RotationSpeed*dt)
-		case ebiten.KeyD:
-			p.Rotate(RotateClockwise, p.RotationSpeed*dt)
+// Update steers the ship with the command of this tick. Firing is up to the
+// caller, which owns the bullets.
+func (p *Player) Update(cmd Command) {
+	if cmd.Thrust {
+		p.thrust(MoveForward)
+	}
+	if cmd.Reverse {
+		p.thrust(MoveBackward)
+	}
+	if cmd.RotateLeft {
+		p.Rotate(RotateAntiClockwise, p.RotationSpeed*dt)
+	}
+	if cmd.RotateRight {
+		p.Rotate(RotateClockwise, p.RotationSpeed*dt)
+	}
+	if p.Flight.Model == FlightInertial {
+		p.Drift(dt)
+	}
+
+	switch p.Boundary {
+	case BoundaryWrap:
+		p.Center.Wrap(p.wrapBounds())
+	default:
+		old := p.Center
+		p.Center.Clamp(p.Bounds)
+		// stop drifting into the edges
+		if p.Center.X != old.X {
+			p.Velocity.X = 0
+		}
+		if p.Center.Y != old.Y {
+			p.Velocity.Y = 0
 		}
 	}
-	p.Center.Clamp(p.Bounds)
+}
+
+func (p *Player) thrust(direction MoveDirection) {
+	switch p.Flight.Model {
+	case FlightInertial:
+		p.Accelerate(direction, p.Flight.Thrust*dt)
+	default:
+		p.Move(direction, p.Speed*dt)
+	}
+}
+
+// wrapBounds returns the full area the ship wraps around. Bounds is inset by
+// the radius to keep a clamped ship on screen.
+func (p *Player) wrapBounds() image.Rectangle {
+	return p.Bounds.Inset(-p.Radius)
+}
+
+// extent returns the distance from the center to the farthest corner of the ship.
+func (p *Player) extent() float64 {
+	r := 0.0
+	for _, v := range p.Triangle() {
+		r = utils.Max(r, utils.Distance(p.Center.X, p.Center.Y, v.X, v.Y))
+	}
+	return r
 }
 
 func (p *Player) Triangle() [3]*utils.Vector2 {
+	return p.TriangleAt(p.Center)
+}
+
+// TriangleAt returns the corners of the ship as if it were at center.
+func (p *Player) TriangleAt(center utils.Vector2) [3]*utils.Vector2 {
 	forward := p.Direction.Clone().Scale(float64(p.Radius))
 
 	right := p.Direction.Clone().Reverse().Rotate(90).Scale(float64(p.Radius) / 1.5)
 
-	a := p.Center.Clone().Add(*forward)
-	b := p.Center.Clone().Sub(*forward).Sub(*right)
-	c := p.Center.Clone().Sub(*forward).Add(*right)
+	a := center.Clone().Add(*forward)
+	b := center.Clone().Sub(*forward).Sub(*right)
+	c := center.Clone().Sub(*forward).Add(*right)
 
 	return [3]*utils.Vector2{a, b, c}
 }
 
-func (p *Player) Draw(screen *ebiten.Image) {
-	var vertices []ebiten.Vertex
-	var indices []uint16
-	var path vector.Path
-
+// Hitbox returns the triangle of the ship, so asteroids only hit what is drawn.
+func (p *Player) Hitbox() physics.Shape {
 	corners := p.Triangle()
+	return physics.Polygon{Points: []utils.Vector2{*corners[0], *corners[1], *corners[2]}}
+}
 
-	path.MoveTo(float32(corners[0].X), float32(corners[0].Y)) // Top vertex
-	path.LineTo(float32(corners[1].X), float32(corners[1].Y)) // Bottom-left vertex
-	path.LineTo(float32(corners[2].X), float32(corners[2].Y)) // Bottom-right vertex
-	path.Close()
+func (p *Player) IsCollided(h Collidable) bool {
+	return physics.Overlaps(p.Hitbox(), h.Hitbox())
+}
 
-	vertices, indices = path.AppendVerticesAndIndicesForFilling(vertices, indices)
+// IsVisible reports whether the ship shows on this tick. It blinks while
+// invulnerable.
+func (p *Player) IsVisible() bool {
+	return !p.IsInvulnerable() || (p.Clock.Now()/blinkPeriod)%2 == 0
+}
 
-	op := &ebiten.DrawTrianglesOptions{}
-	op.AntiAlias = true
-	op.FillRule = ebiten.FillRuleNonZero
-	screen.DrawTriangles(vertices, indices, whiteSubImage, op)
+// ForEachImage calls draw with every position the ship shows at.
+func (p *Player) ForEachImage(draw func(center utils.Vector2)) {
+	forEachImage(p.Center, p.extent(), p.Boundary, p.wrapBounds(), draw)
 }
 
 func (p *Player) Fire() (*Bullet, error) {
-	if time.Since(p.lastFired) < p.Gun.RateLimit {
+	now := p.Clock.Now()
+	if now < p.nextFire {
 		return nil, ErrGunNotReady
 	}
-	p.lastFired = time.Now()
+	p.nextFire = now + p.Gun.RateLimit
 	gunPos := p.Triangle()[0]
 	dir := p.Direction.Clone()
 	bullet := NewBullet(*gunPos, p.Gun.Radius, p.Gun.Speed, *dir)
 	return bullet, nil
 }
 
+// Respawn puts the ship back at center, at rest and facing up, and makes it
+// invulnerable for d.
+func (p *Player) Respawn(center utils.Vector2, d time.Duration) {
+	p.Center = center
+	p.Direction = utils.Vector2{X: 0, Y: -1}
+	p.Velocity = utils.Vector2{}
+	p.MakeInvulnerable(d)
+}
+
+// MakeInvulnerable lets the ship ignore asteroids for d from now.
+func (p *Player) MakeInvulnerable(d time.Duration) {
+	p.invulnerableUntil = p.Clock.Now() + d
+}
+
+func (p *Player) IsInvulnerable() bool {
+	return p.Clock.Now() < p.invulnerableUntil
+}
+
 func (p *Player) Move(direction MoveDirection, distance float64) {
 	var v *utils.Vector2
 	switch direction {
@@ -136,6 +235,30 @@ func (p *Player) Move(direction MoveDirection, distance float64) {
 	p.Center.Add(*v)
 }
 
+// Accelerate changes the velocity of the ship by dv along its heading.
+func (p *Player) Accelerate(direction MoveDirection, dv float64) {
+	var v *utils.Vector2
+	switch direction {
+	case MoveForward:
+		v = p.Direction.Clone().Scale(dv)
+	case MoveBackward:
+		v = p.Direction.Clone().Reverse().Scale(dv)
+	default:
+		return
+	}
+
+	p.Velocity.Add(*v)
+	if l := p.Velocity.Length(); l > p.Speed {
+		p.Velocity.Scale(p.Speed / l)
+	}
+}
+
+// Drift applies drag to the velocity of the ship and moves it for t seconds.
+func (p *Player) Drift(t float64) {
+	p.Velocity.Scale(utils.Max(0, 1-p.Flight.Drag*t))
+	p.Center.Add(*p.Velocity.Clone().Scale(t))
+}
+
 func (p *Player) Rotate(direction RotateDirection, deg float64) {
 	// for drawing, y-axis is inverted. Thus the expected values are inverted
 	switch direction {
@@ -5,9 +5,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/stretchr/testify/assert"
 
+	"asteroid/physics"
 	"asteroid/sprite"
 	"asteroid/utils"
 )
@@ -75,7 +75,11 @@ func TestNewPlayer(t *testing.T) {
 	rotationSpeed := 3.0
 	gunConfig := sprite.GunConfig{Radius: 5, Speed: 10.0, RateLimit: time.Millisecond * 500}
 
-	player := sprite.NewPlayer(center, radius, bounds, speed, rotationSpeed, gunConfig)
+	clock := &sprite.FakeClock{}
+
+	flight := sprite.FlightConfig{Model: sprite.FlightInertial, Thrust: 300, Drag: 0.5}
+
+	player := sprite.NewPlayer(center, radius, bounds, speed, rotationSpeed, gunConfig, flight, clock)
 
 	assert.Equal(center, player.Center)
 	assert.Equal(radius, player.Radius)
@@ -84,6 +88,9 @@ func TestNewPlayer(t *testing.T) {
 	assert.Equal(image.Rect(radius, radius, 640-radius, 480-radius), player.Bounds)
 	assert.Equal(rotationSpeed, player.RotationSpeed)
 	assert.Equal(gunConfig, player.Gun)
+	assert.Equal(flight, player.Flight)
+	assert.Equal(utils.Vector2{}, player.Velocity)
+	assert.Equal(clock, player.Clock)
 }
 
 func TestPlayerUpdate(t *testing.T) {
@@ -96,34 +103,34 @@ func TestPlayerUpdate(t *testing.T) {
 	rotationSpeed := 300.0
 	gun := sprite.GunConfig{Radius: 5, Speed: 10.0, RateLimit: time.Millisecond * 500}
 
-	p := sprite.NewPlayer(center, radius, bounds, speed, rotationSpeed, gun)
+	p := sprite.NewPlayer(center, radius, bounds, speed, rotationSpeed, gun, sprite.FlightConfig{}, &sprite.FakeClock{})
 
 	type Case struct {
 		name     string
-		keys     []ebiten.Key
+		cmd      sprite.Command
 		expected utils.Vector2
 	}
 	moveCases := []Case{
-		{"move forward", []ebiten.Key{ebiten.KeyW}, utils.Vector2{X: 100, Y: 98.33333}},
-		{"move backward", []ebiten.Key{ebiten.KeyS}, utils.Vector2{X: 100, Y: 100}},
+		{"move forward", sprite.Command{Thrust: true}, utils.Vector2{X: 100, Y: 98.33333}},
+		{"move backward", sprite.Command{Reverse: true}, utils.Vector2{X: 100, Y: 100}},
 	}
 
 	for _, c := range moveCases {
 		t.Run(c.name, func(t *testing.T) {
-			p.Update(c.keys)
+			p.Update(c.cmd)
 			assert.InDelta(c.expected.X, p.Center.X, 0.0001)
 			assert.InDelta(c.expected.Y, p.Center.Y, 0.0001)
 		})
 	}
 
 	rotateCases := []Case{
-		{"rotate anti-clockwise", []ebiten.Key{ebiten.KeyA}, utils.Vector2{X: -0.08715, Y: -0.99619}},
-		{"rotate clockwise", []ebiten.Key{ebiten.KeyD}, utils.Vector2{X: 0, Y: -1}},
+		{"rotate anti-clockwise", sprite.Command{RotateLeft: true}, utils.Vector2{X: -0.08715, Y: -0.99619}},
+		{"rotate clockwise", sprite.Command{RotateRight: true}, utils.Vector2{X: 0, Y: -1}},
 	}
 
 	for _, c := range rotateCases {
 		t.Run(c.name, func(t *testing.T) {
-			p.Update(c.keys)
+			p.Update(c.cmd)
 			assert.InDelta(c.expected.X, p.Direction.X, 0.0001)
 			assert.InDelta(c.expected.Y, p.Direction.Y, 0.0001)
 		})
@@ -131,15 +138,78 @@ func TestPlayerUpdate(t *testing.T) {
 
 	t.Run("clamping", func(t *testing.T) {
 		p.Center = utils.Vector2{X: float64(radius - 1), Y: float64(radius - 1)}
-		p.Update([]ebiten.Key{})
+		p.Update(sprite.Command{})
 		assert.Equal(utils.Vector2{X: float64(radius), Y: float64(radius)}, p.Center)
 
 		p.Center = utils.Vector2{X: float64(640 - radius + 1), Y: float64(480 - radius + 1)}
-		p.Update([]ebiten.Key{})
+		p.Update(sprite.Command{})
 		assert.Equal(utils.Vector2{X: float64(640 - radius), Y: float64(480 - radius)}, p.Center)
 	})
 }
 
+func TestPlayerUpdateInertial(t *testing.T) {
+	assert := assert.New(t)
+
+	gun := sprite.GunConfig{Radius: 5, Speed: 10.0, RateLimit: time.Millisecond * 500}
+	flight := sprite.FlightConfig{Model: sprite.FlightInertial, Thrust: 600, Drag: 0.5}
+	p := sprite.NewPlayer(utils.Vector2{X: 320, Y: 240}, 20, image.Rect(0, 0, 640, 480), 100, 300, gun, flight, &sprite.FakeClock{})
+
+	p.Update(sprite.Command{Thrust: true})
+	assert.InDelta(0, p.Velocity.X, 0.0001)
+	assert.Less(p.Velocity.Y, 0.0, "thrust should accelerate the ship forward")
+	assert.Less(p.Center.Y, 240.0)
+
+	// the ship keeps drifting without thrust, slowed down by drag
+	speed := p.Velocity.Length()
+	y := p.Center.Y
+	p.Update(sprite.Command{})
+	assert.Less(p.Center.Y, y, "the ship should keep drifting")
+	assert.Less(p.Velocity.Length(), speed, "drag should slow the ship down")
+
+	// thrusting never exceeds the max speed
+	for i := 0; i < 60; i++ {
+		p.Update(sprite.Command{Thrust: true})
+	}
+	assert.InDelta(100, p.Velocity.Length(), 1)
+
+	// hitting an edge stops drifting into it
+	for i := 0; i < 120; i++ {
+		p.Update(sprite.Command{Thrust: true})
+	}
+	assert.Equal(float64(20), p.Center.Y)
+	assert.Equal(0.0, p.Velocity.Y)
+}
+
+func TestPlayerDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &sprite.Player{
+		Flight:   sprite.FlightConfig{Model: sprite.FlightInertial, Drag: 0.5},
+		Velocity: utils.Vector2{X: 100, Y: 0},
+	}
+	p.Drift(1)
+	assert.Equal(utils.Vector2{X: 50, Y: 0}, p.Velocity)
+	assert.Equal(utils.Vector2{X: 50, Y: 0}, p.Center)
+}
+
+func TestPlayerUpdateWrap(t *testing.T) {
+	assert := assert.New(t)
+
+	radius := 20
+	gun := sprite.GunConfig{Radius: 5, Speed: 10.0, RateLimit: time.Millisecond * 500}
+	p := sprite.NewPlayer(utils.Vector2{X: 100, Y: 100}, radius, image.Rect(0, 0, 640, 480), 100, 300, gun, sprite.FlightConfig{}, &sprite.FakeClock{})
+	p.Boundary = sprite.BoundaryWrap
+
+	// wraps around the whole screen, not the clamping bounds
+	p.Center = utils.Vector2{X: -1, Y: 481}
+	p.Update(sprite.Command{})
+	assert.Equal(utils.Vector2{X: 639, Y: 1}, p.Center)
+
+	p.Center = utils.Vector2{X: float64(radius - 1), Y: 100}
+	p.Update(sprite.Command{})
+	assert.Equal(utils.Vector2{X: float64(radius - 1), Y: 100}, p.Center)
+}
+
 func TestPlayerTriangle(t *testing.T) {
 	assert := assert.New(t)
 
@@ -241,10 +311,12 @@ func TestPlayerGetHitboxCircle(t *testing.T) {
 func TestPlayerIsCollided(t *testing.T) {
 	assert := assert.New(t)
 
+	// the triangle is (100, 80), (113.33, 120), (86.67, 120)
 	p := &sprite.Player{
 		Circle: sprite.Circle{
-			Center: utils.Vector2{X: 100, Y: 100},
-			Radius: 20,
+			Center:    utils.Vector2{X: 100, Y: 100},
+			Radius:    20,
+			Direction: utils.Vector2{X: 0, Y: -1},
 		},
 	}
 	// Test cases
@@ -272,11 +344,27 @@ func TestPlayerIsCollided(t *testing.T) {
 		{
 			name: "Edge Collision",
 			hitbox: mockCollidable{
-				Center: utils.Vector2{X: 130, Y: 100},
+				Center: utils.Vector2{X: 100, Y: 70},
 				Radius: 10,
 			},
 			collided: true,
 		},
+		{
+			name: "Inside Triangle",
+			hitbox: mockCollidable{
+				Center: utils.Vector2{X: 100, Y: 110},
+				Radius: 1,
+			},
+			collided: true,
+		},
+		{
+			name: "Inside Bounding Circle Only",
+			hitbox: mockCollidable{
+				Center: utils.Vector2{X: 130, Y: 100},
+				Radius: 10,
+			},
+			collided: false,
+		},
 	}
 
 	for _, c := range cases {
@@ -287,15 +375,33 @@ func TestPlayerIsCollided(t *testing.T) {
 	}
 }
 
+func TestPlayerHitbox(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &sprite.Player{
+		Circle: sprite.Circle{
+			Center:    utils.Vector2{X: 100, Y: 100},
+			Radius:    30,
+			Direction: utils.Vector2{X: 0, Y: -1},
+		},
+	}
+
+	hitbox, ok := p.Hitbox().(physics.Polygon)
+	assert.True(ok, "player hitbox should be a polygon")
+	assert.Equal([]utils.Vector2{{X: 100, Y: 70}, {X: 120, Y: 130}, {X: 80, Y: 130}}, hitbox.Points)
+}
+
 func TestPlayerFire(t *testing.T) {
 	assert := assert.New(t)
 	gunConfig := sprite.GunConfig{Radius: 5, Speed: 10.0, RateLimit: time.Millisecond * 500}
+	clock := &sprite.FakeClock{}
 	p := &sprite.Player{
 		Circle: sprite.Circle{
 			Center: utils.Vector2{X: 100, Y: 100},
 			Radius: 20,
 		},
-		Gun: gunConfig,
+		Gun:   gunConfig,
+		Clock: clock,
 	}
 
 	// First fire
@@ -307,11 +413,41 @@ func TestPlayerFire(t *testing.T) {
 	_, err = p.Fire()
 	assert.Equal(sprite.ErrGunNotReady, err)
 
+	// Just before the rate limit
+	clock.Advance(gunConfig.RateLimit - time.Millisecond)
+	_, err = p.Fire()
+	assert.Equal(sprite.ErrGunNotReady, err)
+
 	// Wait for rate limit
-	time.Sleep(gunConfig.RateLimit)
+	clock.Advance(time.Millisecond)
 
 	// Fire again after rate limit
 	bullet, err = p.Fire()
 	assert.NoError(err)
 	assert.NotNil(bullet)
 }
+
+func TestPlayerRespawn(t *testing.T) {
+	assert := assert.New(t)
+	clock := &sprite.FakeClock{}
+	p := &sprite.Player{
+		Circle: sprite.Circle{
+			Center:    utils.Vector2{X: 10, Y: 10},
+			Direction: utils.Vector2{X: 1, Y: 0},
+		},
+		Velocity: utils.Vector2{X: 50, Y: 0},
+		Clock:    clock,
+	}
+	assert.False(p.IsInvulnerable())
+
+	p.Respawn(utils.Vector2{X: 100, Y: 200}, 2*time.Second)
+	assert.Equal(utils.Vector2{X: 100, Y: 200}, p.Center)
+	assert.Equal(utils.Vector2{X: 0, Y: -1}, p.Direction)
+	assert.Equal(utils.Vector2{}, p.Velocity)
+	assert.True(p.IsInvulnerable())
+
+	clock.Advance(1999 * time.Millisecond)
+	assert.True(p.IsInvulnerable())
+	clock.Advance(time.Millisecond)
+	assert.False(p.IsInvulnerable())
+}
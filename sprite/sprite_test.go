@@ -1,6 +1,7 @@
 package sprite_test
 
 import (
+	"asteroid/physics"
 	"asteroid/sprite"
 	"asteroid/utils"
 )
@@ -14,6 +15,10 @@ func (m mockCollidable) GetHitboxCircule() (utils.Vector2, int) {
 	return m.Center, m.Radius
 }
 
+func (m mockCollidable) Hitbox() physics.Shape {
+	return physics.Circle{Center: m.Center, Radius: float64(m.Radius)}
+}
+
 func (m mockCollidable) IsCollided(h sprite.Collidable) bool {
 	return false
 }
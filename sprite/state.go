@@ -0,0 +1,176 @@
+package sprite
+
+import (
+	"asteroid/utils"
+	"log"
+	"math/rand/v2"
+	"time"
+)
+
+// The states below are what changes about the entities while a game is
+// played, unexported fields included, so a game can be saved and resumed.
+// Settings such as speeds and bounds are left to whoever restores them.
+
+// BodyState is the state of an asteroid or a bullet.
+type BodyState struct {
+	Center    utils.Vector2 `json:"center"`
+	Radius    int           `json:"radius"`
+	Speed     float64       `json:"speed"`
+	Direction utils.Vector2 `json:"direction"`
+	Destroyed bool          `json:"destroyed,omitempty"`
+	// Travelled is the distance a bullet has flown.
+	Travelled float64 `json:"travelled,omitempty"`
+}
+
+func (c *Circle) state() BodyState {
+	return BodyState{
+		Center:    c.Center,
+		Radius:    c.Radius,
+		Speed:     c.Speed,
+		Direction: c.Direction,
+		Destroyed: c.destoryed,
+	}
+}
+
+func restoreCircle(s BodyState) Circle {
+	return Circle{
+		Center:    s.Center,
+		Radius:    s.Radius,
+		Speed:     s.Speed,
+		Direction: s.Direction,
+		destoryed: s.Destroyed,
+	}
+}
+
+func (a *Asteroid) State() BodyState {
+	return a.state()
+}
+
+// RestoreAsteroid returns the asteroid s is the state of.
+func RestoreAsteroid(s BodyState) *Asteroid {
+	return &Asteroid{Circle: restoreCircle(s)}
+}
+
+func (b *Bullet) State() BodyState {
+	s := b.state()
+	s.Travelled = b.travelled
+	return s
+}
+
+// RestoreBullet returns the bullet s is the state of.
+func RestoreBullet(s BodyState) *Bullet {
+	return &Bullet{Circle: restoreCircle(s), travelled: s.Travelled}
+}
+
+type PlayerState struct {
+	Center            utils.Vector2 `json:"center"`
+	Direction         utils.Vector2 `json:"direction"`
+	Velocity          utils.Vector2 `json:"velocity"`
+	Destroyed         bool          `json:"destroyed,omitempty"`
+	NextFire          time.Duration `json:"next_fire"`
+	InvulnerableUntil time.Duration `json:"invulnerable_until"`
+}
+
+func (p *Player) State() PlayerState {
+	return PlayerState{
+		Center:            p.Center,
+		Direction:         p.Direction,
+		Velocity:          p.Velocity,
+		Destroyed:         p.destoryed,
+		NextFire:          p.nextFire,
+		InvulnerableUntil: p.invulnerableUntil,
+	}
+}
+
+// Restore puts the ship back in state s.
+func (p *Player) Restore(s PlayerState) {
+	p.Center = s.Center
+	p.Direction = s.Direction
+	p.Velocity = s.Velocity
+	p.destoryed = s.Destroyed
+	p.nextFire = s.NextFire
+	p.invulnerableUntil = s.InvulnerableUntil
+}
+
+type AsteroidControlState struct {
+	Asteroids []BodyState   `json:"asteroids"`
+	Wave      Wave          `json:"wave"`
+	ToSpawn   int           `json:"to_spawn"`
+	NextSpawn time.Duration `json:"next_spawn"`
+	WaveStart time.Duration `json:"wave_start"`
+	NextEvent int           `json:"next_event"`
+	// RNG is the state of the random number generator, so the asteroids to
+	// come are the same as if the game had not been interrupted.
+	RNG []byte `json:"rng"`
+}
+
+func (c *AsteroidControl) State() AsteroidControlState {
+	s := c.SpawnState()
+	s.Asteroids = make([]BodyState, len(c.Asteroids))
+	s.RNG = c.AppendRNG(nil)
+	for i, a := range c.Asteroids {
+		s.Asteroids[i] = a.State()
+	}
+	return s
+}
+
+// SpawnState returns the state of c without its asteroids and generator, which
+// are costly to copy. They are read one by one and with AppendRNG instead.
+func (c *AsteroidControl) SpawnState() AsteroidControlState {
+	return AsteroidControlState{
+		Wave:      c.Wave,
+		ToSpawn:   c.toSpawn,
+		NextSpawn: c.nextSpawn,
+		WaveStart: c.waveStart,
+		NextEvent: c.nextEvent,
+	}
+}
+
+// AppendRNG appends the state of the random number generator to b.
+func (c *AsteroidControl) AppendRNG(b []byte) []byte {
+	b, err := c.pcg.AppendBinary(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return b
+}
+
+// Restore puts the asteroids and the spawning back in state s.
+func (c *AsteroidControl) Restore(s AsteroidControlState) error {
+	pcg := &rand.PCG{}
+	if err := pcg.UnmarshalBinary(s.RNG); err != nil {
+		return err
+	}
+	c.pcg = pcg
+	c.rng = rand.New(pcg)
+	c.Asteroids = make([]*Asteroid, len(s.Asteroids))
+	for i, a := range s.Asteroids {
+		c.Asteroids[i] = RestoreAsteroid(a)
+	}
+	c.Wave = s.Wave
+	c.toSpawn = s.ToSpawn
+	c.nextSpawn = s.NextSpawn
+	c.waveStart = s.WaveStart
+	c.nextEvent = s.NextEvent
+	return nil
+}
+
+type BulletControlState struct {
+	Bullets []BodyState `json:"bullets"`
+}
+
+func (bc *BulletControl) State() BulletControlState {
+	s := BulletControlState{Bullets: make([]BodyState, len(bc.Bullets))}
+	for i, b := range bc.Bullets {
+		s.Bullets[i] = b.State()
+	}
+	return s
+}
+
+// Restore puts the bullets back in state s.
+func (bc *BulletControl) Restore(s BulletControlState) {
+	bc.Bullets = make([]*Bullet, len(s.Bullets))
+	for i, b := range s.Bullets {
+		bc.Bullets[i] = RestoreBullet(b)
+	}
+}
@@ -0,0 +1,76 @@
+package sprite_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/sprite"
+	"asteroid/utils"
+)
+
+func TestBulletState(t *testing.T) {
+	assert := assert.New(t)
+	b := sprite.NewBullet(utils.Vector2{X: 10, Y: 20}, 5, 300, utils.Vector2{X: 1, Y: 0})
+	b.Update()
+	b.Destory()
+
+	restored := sprite.RestoreBullet(b.State())
+	assert.Equal(b, restored)
+	assert.True(restored.IsDestoryed())
+	assert.Equal(b.Travelled(), restored.Travelled())
+}
+
+func TestPlayerState(t *testing.T) {
+	assert := assert.New(t)
+	clock := &sprite.FakeClock{}
+	gun := sprite.GunConfig{Radius: 5, Speed: 10, RateLimit: time.Second}
+	p := sprite.NewPlayer(utils.Vector2{X: 320, Y: 240}, 20, image.Rect(0, 0, 640, 480), 100, 300, gun, sprite.FlightConfig{Model: sprite.FlightInertial, Thrust: 600}, clock)
+	p.Update(sprite.Command{Thrust: true, RotateLeft: true})
+	p.MakeInvulnerable(time.Second)
+	_, err := p.Fire()
+	assert.NoError(err)
+
+	restored := sprite.NewPlayer(utils.Vector2{}, 20, image.Rect(0, 0, 640, 480), 100, 300, gun, sprite.FlightConfig{Model: sprite.FlightInertial, Thrust: 600}, clock)
+	restored.Restore(p.State())
+	assert.Equal(p, restored)
+	_, err = restored.Fire()
+	assert.ErrorIs(err, sprite.ErrGunNotReady, "the gun is still cooling down")
+	assert.True(restored.IsInvulnerable())
+}
+
+func TestAsteroidControlState(t *testing.T) {
+	assert := assert.New(t)
+	newControl := func(clock sprite.Clock) *sprite.AsteroidControl {
+		return sprite.NewAsteroidControl(20, 3, image.Rect(0, 0, 640, 480), time.Second, 100, 40, 30, 7, clock)
+	}
+	clock := &sprite.FakeClock{}
+	c := newControl(clock)
+	c.StartWave(sprite.Wave{Count: 6, SpawnRate: time.Second, MinSpeed: 40, MaxSpeed: 100})
+	for range 3 {
+		c.Update()
+		clock.Advance(time.Second)
+	}
+	c.HitAsteroid(0)
+
+	restored := newControl(clock)
+	assert.NoError(restored.Restore(c.State()))
+	assert.Equal(c.Asteroids, restored.Asteroids)
+	assert.Equal(c.Wave, restored.Wave)
+
+	// both spawn the rest of the wave the same way
+	for range 4 {
+		c.Update()
+		restored.Update()
+		clock.Advance(time.Second)
+	}
+	assert.Len(restored.Asteroids, len(c.Asteroids))
+	assert.Equal(c.Asteroids, restored.Asteroids)
+	assert.True(restored.IsWaveCleared() == c.IsWaveCleared())
+
+	s := c.State()
+	s.RNG = []byte("not a generator")
+	assert.Error(restored.Restore(s))
+}
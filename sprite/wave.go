@@ -0,0 +1,81 @@
+package sprite
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Edges of the bounds asteroids spawn on.
+const (
+	EdgeTop = iota
+	EdgeRight
+	EdgeBottom
+	EdgeLeft
+	// EdgeRandom picks one of the four edges at random.
+	EdgeRandom = -1
+)
+
+// Formation is how the asteroids of a SpawnEvent are placed on their edge.
+type Formation int
+
+const (
+	// FormationScatter spreads the asteroids over random points of the edge.
+	FormationScatter Formation = iota
+	// FormationLine lines the asteroids up along the edge, Spacing pixels
+	// apart, all moving the same way at the same speed.
+	FormationLine
+	// FormationFan sends the asteroids from a single point of the edge at the
+	// same speed, their headings Spacing degrees apart.
+	FormationFan
+)
+
+// SpawnEvent spawns a group of asteroids At a time after the start of its wave.
+type SpawnEvent struct {
+	At   time.Duration
+	Edge int
+	// Size is the radius of the asteroids in multiples of the smallest radius.
+	// 0 picks the size of every asteroid from the size mix of the wave.
+	Size  int
+	Count int
+	// MinSpeed and MaxSpeed default to the speeds of the wave when both are 0.
+	MinSpeed  float64
+	MaxSpeed  float64
+	Formation Formation
+	Spacing   float64
+}
+
+// Wave is a group of asteroids sent at the player. Count asteroids are spawned
+// one at a time from random edges, while Events are spawned at set times.
+type Wave struct {
+	// Count is how many asteroids the wave spawns at random.
+	Count int
+	// Sizes is the relative chance of each size tier, from the smallest up.
+	// Tiers past its end are never spawned, no weights at all spawns every
+	// tier with the same chance.
+	Sizes     []int
+	MinSpeed  float64
+	MaxSpeed  float64
+	SpawnRate time.Duration
+	// Events are sorted by the time they happen at.
+	Events []SpawnEvent
+}
+
+// tier picks the size tier of the next asteroid, out of kind tiers.
+func (w *Wave) tier(rng *rand.Rand, kind int) int {
+	total := 0
+	for _, s := range w.Sizes[:min(len(w.Sizes), kind)] {
+		total += max(s, 0)
+	}
+	if total == 0 {
+		return rng.IntN(kind)
+	}
+
+	r := rng.IntN(total)
+	for i, s := range w.Sizes {
+		r -= max(s, 0)
+		if r < 0 {
+			return i
+		}
+	}
+	return kind - 1
+}
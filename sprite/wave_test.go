@@ -0,0 +1,50 @@
+package sprite_test
+
+import (
+	"image"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/sprite"
+)
+
+func TestWaveSizes(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewPCG(1, 1))
+	af := sprite.NewAsteroidFactory(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, 100, 40, 30)
+
+	counts := func(w sprite.Wave) []int {
+		c := make([]int, 3)
+		for range 3000 {
+			c[af.NewWaveAsteroid(rng, 0, w).Radius/20-1]++
+		}
+		return c
+	}
+
+	c := counts(sprite.Wave{Sizes: []int{0, 1, 3}})
+	assert.Equal(0, c[0], "tiers without weight never spawn")
+	assert.InDelta(3, float64(c[2])/float64(c[1]), 0.3)
+
+	c = counts(sprite.Wave{})
+	for _, n := range c {
+		assert.InDelta(1000, n, 150, "no weights spawn every tier alike")
+	}
+
+	c = counts(sprite.Wave{Sizes: []int{1, 0, 0, 5}})
+	assert.Equal([]int{3000, 0, 0}, c, "weights past the last tier are ignored")
+}
+
+func TestWaveSpeeds(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewPCG(1, 1))
+	af := sprite.NewAsteroidFactory(20, 3, image.Rectangle{Max: image.Point{X: 1000, Y: 1000}}, 100, 40, 30)
+
+	for range 100 {
+		a := af.NewWaveAsteroid(rng, 1, sprite.Wave{MinSpeed: 150, MaxSpeed: 160})
+		assert.GreaterOrEqual(a.Speed, 150.0)
+		assert.Less(a.Speed, 160.0)
+	}
+	assert.Equal(90.0, af.NewWaveAsteroid(rng, 1, sprite.Wave{MinSpeed: 90, MaxSpeed: 90}).Speed)
+}
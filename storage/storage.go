@@ -0,0 +1,52 @@
+// Package storage keeps the files of the game under the user's config
+// directory and writes them without ever leaving one half written.
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// appDir is the directory of the game under the user's config directory.
+const appDir = "simple-asteroid-game"
+
+// Path returns elem joined under the directory of the game in the user's
+// config directory.
+func Path(elem ...string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{dir, appDir}, elem...)...), nil
+}
+
+// WriteFile replaces the file at path with what write writes, creating its
+// directory if needed. It writes to a temporary file next to path and renames
+// it into place, so a crash while saving never leaves a half written file
+// behind.
+func WriteFile(path string, write func(w io.Writer) error) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// a no-op once the rename succeeded
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,50 @@
+package storage_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"asteroid/storage"
+)
+
+func TestPath(t *testing.T) {
+	assert := assert.New(t)
+	path, err := storage.Path("replays")
+	if err != nil {
+		t.Skip("no config directory:", err)
+	}
+	assert.Equal(filepath.Join("simple-asteroid-game", "replays"), filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path)))
+}
+
+func TestWriteFile(t *testing.T) {
+	assert := assert.New(t)
+	dir := filepath.Join(t.TempDir(), "nested")
+	path := filepath.Join(dir, "data.json")
+	write := func(s string) func(io.Writer) error {
+		return func(w io.Writer) error {
+			_, err := io.WriteString(w, s)
+			return err
+		}
+	}
+
+	assert.NoError(storage.WriteFile(path, write("first")), "the directory is created")
+	assert.NoError(storage.WriteFile(path, write("second")))
+	data, _ := os.ReadFile(path)
+	assert.Equal("second", string(data))
+
+	failed := errors.New("disk full")
+	assert.ErrorIs(storage.WriteFile(path, func(w io.Writer) error {
+		io.WriteString(w, "half")
+		return failed
+	}), failed)
+	data, _ = os.ReadFile(path)
+	assert.Equal("second", string(data), "a failed write leaves the file as it was")
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(entries, 1, "no temporary file is left behind")
+}
@@ -27,6 +27,19 @@ func Clamp[T cmp.Ordered](x, min, max T) T {
 	return Min(Max(min, x), max)
 }
 
+// Wrap wraps x into [min, max), as if the range were circular.
+func Wrap(x, min, max float64) float64 {
+	w := max - min
+	if w <= 0 {
+		return min
+	}
+	x = math.Mod(x-min, w)
+	if x < 0 {
+		x += w
+	}
+	return x + min
+}
+
 func Distance[T Numeric](x1, y1, x2, y2 T) float64 {
 	dx := x1 - x2
 	dy := y1 - y2
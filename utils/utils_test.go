@@ -53,6 +53,18 @@ func TestClamp(t *testing.T) {
 	assert.Equal(10.0, utils.Clamp(15.0, 1.0, 10.0), "float64: The clamp[1, 10] of 15.0 should be 10.0")
 }
 
+func TestWrap(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(5.0, utils.Wrap(5, 0, 10), "inside the range stays put")
+	assert.Equal(0.0, utils.Wrap(10, 0, 10), "max wraps to min")
+	assert.Equal(2.0, utils.Wrap(12, 0, 10), "over max wraps from min")
+	assert.Equal(8.0, utils.Wrap(-2, 0, 10), "under min wraps from max")
+	assert.Equal(8.0, utils.Wrap(28, 0, 10), "wraps several times")
+	assert.Equal(15.0, utils.Wrap(25, 10, 20), "non-zero min")
+	assert.Equal(3.0, utils.Wrap(5, 3, 3), "empty range returns min")
+}
+
 func TestDistance(t *testing.T) {
 	assert := assert.New(t)
 
@@ -66,6 +66,12 @@ func (v *Vector2) Clamp(bounds image.Rectangle) {
 	v.Y = Clamp(v.Y, float64(bounds.Min.X), float64(bounds.Max.Y))
 }
 
+// Wrap wraps the vector around the given bounds, as if they were a torus.
+func (v *Vector2) Wrap(bounds image.Rectangle) {
+	v.X = Wrap(v.X, float64(bounds.Min.X), float64(bounds.Max.X))
+	v.Y = Wrap(v.Y, float64(bounds.Min.Y), float64(bounds.Max.Y))
+}
+
 func (v *Vector2) Reverse() *Vector2 {
 	v.X = -v.X
 	v.Y = -v.Y
@@ -179,6 +179,31 @@ func TestClampVector2(t *testing.T) {
 	}
 }
 
+func TestWrapVector2(t *testing.T) {
+	assert := assert.New(t)
+
+	testBox := image.Rect(0, 0, 10, 20)
+	cases := []struct {
+		name     string
+		vector2  Vector2
+		expected Vector2
+	}{
+		{"vector2 inside box", Vector2{X: 5, Y: 5}, Vector2{X: 5, Y: 5}},
+		{"vector2 right of box", Vector2{X: 12, Y: 5}, Vector2{X: 2, Y: 5}},
+		{"vector2 left of box", Vector2{X: -1, Y: 5}, Vector2{X: 9, Y: 5}},
+		{"vector2 under box", Vector2{X: 5, Y: 23}, Vector2{X: 5, Y: 3}},
+		{"vector2 over box", Vector2{X: 5, Y: -3}, Vector2{X: 5, Y: 17}},
+		{"vector2 off a corner", Vector2{X: 11, Y: 21}, Vector2{X: 1, Y: 1}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.vector2.Wrap(testBox)
+			assert.Equal(c.expected, c.vector2, "The point should be wrapped around the box")
+		})
+	}
+}
+
 func TestReverse(t *testing.T) {
 	assert := assert.New(t)
 